@@ -0,0 +1,123 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	mockdb "simple-bank/db/mock"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteAccessTableCoversRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mockdb.NewMockStore(ctrl))
+
+	// Routes that are reached without an access token
+	publicRoutes := map[string]bool{
+		routeKey(http.MethodPost, "/users"):        true,
+		routeKey(http.MethodPost, "/users/login"):  true,
+		routeKey(http.MethodPost, "/tokens/renew"): true,
+		routeKey(http.MethodPost, "/users/logout"): true,
+	}
+
+	for _, route := range server.router.Routes() {
+		key := routeKey(route.Method, route.Path)
+		if publicRoutes[key] {
+			continue
+		}
+		_, ok := routeAccessTable[key]
+		require.True(t, ok, "missing access rule for %s", key)
+	}
+}
+
+func TestAccessMiddleware(t *testing.T) {
+	table := map[string]rbac.Permission{
+		routeKey(http.MethodGet, "/any"):    "",
+		routeKey(http.MethodGet, "/banker"): rbac.MoveCash,
+	}
+
+	testCases := []struct {
+		name      string
+		path      string
+		setupAuth func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		code      int
+	}{
+		{
+			name: "AnyRole",
+			path: "/any",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", rbac.DepositorRole, time.Minute)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "Banker",
+			path: "/banker",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", rbac.BankerRole, time.Minute)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name: "DepositorWithoutPermission",
+			path: "/banker",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", rbac.DepositorRole, time.Minute)
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			// A route missing from the table is rejected even for bankers
+			name: "UnknownRoute",
+			path: "/unknown",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "user", rbac.BankerRole, time.Minute)
+			},
+			code: http.StatusForbidden,
+		},
+		{
+			name:      "NoAuthorization",
+			path:      "/banker",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
+			code:      http.StatusUnauthorized,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			routes := server.router.Group("/").Use(
+				authMiddleware(server.tokenMaker, server.sessionCache),
+				accessMiddleware(table),
+			)
+			for _, path := range []string{"/any", "/banker", "/unknown"} {
+				routes.GET(path, func(ctx *gin.Context) {
+					ctx.JSON(http.StatusOK, gin.H{})
+				})
+			}
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.code, recorder.Code)
+		})
+	}
+}
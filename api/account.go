@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	db "simple-bank/db/sqlc"
+	"simple-bank/pagination"
 	"simple-bank/token"
 
 	"github.com/gin-gonic/gin"
@@ -83,7 +84,7 @@ func (server *Server) getAccount(ctx *gin.Context) {
 	fmt.Printf("Authenticated user: %s\n", authPayload.Username)
 	fmt.Printf("Account owner: %s\n", account.Owner)
 
-	if account.Owner != authPayload.Username {
+	if !canViewAccount(authPayload, account) {
 		err := errors.New("account doesn't belong to the authenticated user")
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
@@ -93,8 +94,15 @@ func (server *Server) getAccount(ctx *gin.Context) {
 }
 
 type getAccountsRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,page_size"`
+	// `next_page_token` of the previous page, empty for the first page
+	PageToken string `form:"page_token"`
+}
+
+type getAccountsResponse struct {
+	Accounts []db.Account `json:"accounts"`
+	// Empty on the last page
+	NextPageToken string `json:"next_page_token"`
 }
 
 func (server *Server) getAccounts(ctx *gin.Context) {
@@ -106,22 +114,35 @@ func (server *Server) getAccounts(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
+	scope := pagination.AccountsScope(authPayload.Username)
+	afterID, err := server.pageTokenMaker.VerifyToken(req.PageToken, scope)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  int32(req.PageSize),
-		Offset: int32(req.PageID-1) * req.PageSize,
+		Owner:   authPayload.Username,
+		AfterID: afterID,
+		Limit:   pagination.Limit(req.PageSize),
 	}
 
 	accounts, err := server.store.ListAccounts(ctx, arg)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, accounts)
+	accounts, hasNextPage := pagination.Page(accounts, req.PageSize)
+
+	rsp := getAccountsResponse{Accounts: accounts}
+	if hasNextPage {
+		rsp.NextPageToken, err = server.pageTokenMaker.CreateToken(scope, accounts[len(accounts)-1].ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
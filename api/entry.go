@@ -0,0 +1,143 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pagination"
+	"simple-bank/token"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type accountHistoryURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// Query parameters shared by the entry and transfer history of an account
+type accountHistoryRequest struct {
+	PageSize int32 `form:"page_size" binding:"required,page_size"`
+	// `next_page_token` of the previous page, empty for the first page
+	PageToken string `form:"page_token"`
+	// RFC 3339 timestamps, eg: 2025-01-31T00:00:00Z
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	Direction string     `form:"direction" binding:"omitempty,oneof=incoming outgoing"`
+	MinAmount *int64     `form:"min_amount" binding:"omitempty,min=0"`
+	MaxAmount *int64     `form:"max_amount" binding:"omitempty,min=0"`
+}
+
+type listAccountEntriesResponse struct {
+	Entries []db.Entry `json:"entries"`
+	// Empty on the last page
+	NextPageToken string `json:"next_page_token"`
+}
+
+func (req accountHistoryRequest) validate() error {
+	if req.StartTime != nil && req.EndTime != nil && !req.StartTime.Before(*req.EndTime) {
+		return errors.New("end_time must be after start_time")
+	}
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		return errors.New("max_amount must not be less than min_amount")
+	}
+	return nil
+}
+
+// Binds the account history request and checks that the account belongs to the logged in user
+func (server *Server) bindAccountHistoryRequest(ctx *gin.Context) (accountHistoryRequest, int64, bool) {
+	var uri accountHistoryURI
+	var req accountHistoryRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return req, 0, false
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return req, 0, false
+	}
+
+	if err := req.validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return req, 0, false
+	}
+
+	account, err := server.store.GetAccount(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return req, 0, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return req, 0, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !canViewAccount(authPayload, account) {
+		err := errors.New("account doesn't belong to the authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return req, 0, false
+	}
+
+	return req, account.ID, true
+}
+
+func (server *Server) listAccountEntries(ctx *gin.Context) {
+	req, accountID, ok := server.bindAccountHistoryRequest(ctx)
+	if !ok {
+		return
+	}
+
+	scope := pagination.EntriesScope(accountID)
+	afterID, err := server.pageTokenMaker.VerifyToken(req.PageToken, scope)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListEntriesParams{
+		AccountID: accountID,
+		AfterID:   afterID,
+		StartTime: nullTime(req.StartTime),
+		EndTime:   nullTime(req.EndTime),
+		Direction: sql.NullString{String: req.Direction, Valid: req.Direction != ""},
+		MinAmount: nullInt64(req.MinAmount),
+		MaxAmount: nullInt64(req.MaxAmount),
+		Limit:     pagination.Limit(req.PageSize),
+	}
+
+	entries, err := server.store.ListEntries(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	entries, hasNextPage := pagination.Page(entries, req.PageSize)
+
+	rsp := listAccountEntriesResponse{Entries: entries}
+	if hasNextPage {
+		rsp.NextPageToken, err = server.pageTokenMaker.CreateToken(scope, entries[len(entries)-1].ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+func nullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *value, Valid: true}
+}
+
+func nullInt64(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
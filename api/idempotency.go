@@ -0,0 +1,141 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	db "simple-bank/db/sqlc"
+	"simple-bank/token"
+	"simple-bank/val"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// Captures the response body so it can be saved against the idempotency key
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (rec *bodyRecorder) Write(body []byte) (int, error) {
+	rec.body.Write(body)
+	return rec.ResponseWriter.Write(body)
+}
+
+// Replays the saved response when a request is retried with the same `Idempotency-Key` header.
+// It must run after `authMiddleware` since keys are scoped to the logged in user.
+func idempotencyMiddleware(store db.Store) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(idempotencyKeyHeader)
+		if len(key) == 0 {
+			ctx.Next()
+			return
+		}
+
+		if err := val.ValidateIdempotencyKey(key); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		// The handler still needs to bind the body
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		requestHash := hashRequest(ctx.Request.Method, ctx.FullPath(), body)
+
+		idempotencyKey, err := store.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{
+			Username:    authPayload.Username,
+			Key:         key,
+			RequestHash: requestHash,
+			ClaimID:     uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		})
+		if err != nil {
+			if err != sql.ErrNoRows {
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+
+			// The key was used before
+			idempotencyKey, err = store.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+				Username: authPayload.Username,
+				Key:      key,
+			})
+			if err != nil {
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+
+			if idempotencyKey.RequestHash != requestHash {
+				err := errors.New("idempotency key was already used with a different request")
+				ctx.AbortWithStatusJSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+
+			// The request that claimed the key may have committed before it stopped,
+			// so the key is never claimed again
+			if !idempotencyKey.ResponseCode.Valid {
+				err := errors.New("a request with this idempotency key is being processed or did not finish")
+				ctx.AbortWithStatusJSON(http.StatusConflict, errorResponse(err))
+				return
+			}
+
+			ctx.Data(int(idempotencyKey.ResponseCode.Int32), gin.MIMEJSON, idempotencyKey.ResponseBody)
+			ctx.Abort()
+			return
+		}
+
+		rec := &bodyRecorder{ResponseWriter: ctx.Writer, body: &bytes.Buffer{}}
+		ctx.Writer = rec
+		ctx.Next()
+
+		// The key is released and the response saved even if the client went away in the meantime
+		cleanupCtx := context.WithoutCancel(ctx.Request.Context())
+
+		// Only successful responses are saved, a failed request can be retried with the same key.
+		// If the key can't be deleted or the response saved, it stays claimed and its retries are rejected.
+		statusCode := ctx.Writer.Status()
+		if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+			err := store.DeleteIdempotencyKey(cleanupCtx, db.DeleteIdempotencyKeyParams{
+				Username: idempotencyKey.Username,
+				Key:      idempotencyKey.Key,
+				ClaimID:  idempotencyKey.ClaimID,
+			})
+			if err != nil {
+				log.Error().Err(err).Str("path", ctx.FullPath()).Msg("cannot delete idempotency key")
+			}
+			return
+		}
+
+		_, err = store.UpdateIdempotencyKeyResponse(cleanupCtx, db.UpdateIdempotencyKeyResponseParams{
+			Username:     idempotencyKey.Username,
+			Key:          idempotencyKey.Key,
+			ClaimID:      idempotencyKey.ClaimID,
+			ResponseCode: sql.NullInt32{Int32: int32(statusCode), Valid: true},
+			ResponseBody: rec.body.Bytes(),
+		})
+		if err != nil {
+			log.Error().Err(err).Str("path", ctx.FullPath()).Msg("cannot save idempotent response")
+		}
+	}
+}
+
+func hashRequest(method string, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
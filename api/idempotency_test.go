@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/rbac"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type eqCreateIdempotencyKeyParamsMatcher struct {
+	arg db.CreateIdempotencyKeyParams
+}
+
+// Each request claims the key with a new claim id, so only its presence is checked
+func (e eqCreateIdempotencyKeyParamsMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.CreateIdempotencyKeyParams)
+	if !ok || !arg.ClaimID.Valid {
+		return false
+	}
+
+	e.arg.ClaimID = arg.ClaimID
+	return reflect.DeepEqual(e.arg, arg)
+}
+
+func (e eqCreateIdempotencyKeyParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v with a claim id", e.arg)
+}
+
+func EqCreateIdempotencyKeyParams(arg db.CreateIdempotencyKeyParams) gomock.Matcher {
+	return eqCreateIdempotencyKeyParamsMatcher{arg}
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	username := "user"
+	key := "7d5c1f0e-key"
+	idempotentPath := "/idempotent"
+	body := []byte(`{"amount":10}`)
+	requestHash := hashRequest(http.MethodPost, idempotentPath, body)
+	claimID := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	idempotencyKey := db.IdempotencyKey{Username: username, Key: key, RequestHash: requestHash, ClaimID: claimID}
+
+	testCases := []struct {
+		name           string
+		idempotencyKey string
+		handlerStatus  int
+		buildStubs     func(store *mockdb.MockStore)
+		expectedCalls  int
+		checkResponse  func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:           "NoKey",
+			idempotencyKey: "",
+			handlerStatus:  http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedCalls: 1,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:           "FirstRequest",
+			idempotencyKey: key,
+			handlerStatus:  http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), EqCreateIdempotencyKeyParams(db.CreateIdempotencyKeyParams{
+						Username:    username,
+						Key:         key,
+						RequestHash: requestHash,
+					})).
+					Times(1).
+					Return(idempotencyKey, nil)
+				store.EXPECT().
+					UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Eq(db.UpdateIdempotencyKeyResponseParams{
+						Username:     username,
+						Key:          key,
+						ClaimID:      claimID,
+						ResponseCode: sql.NullInt32{Int32: http.StatusOK, Valid: true},
+						ResponseBody: []byte(`{"handled":true}`),
+					})).
+					Times(1)
+			},
+			expectedCalls: 1,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:           "Replay",
+			idempotencyKey: key,
+			handlerStatus:  http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{
+						Username:     username,
+						Key:          key,
+						RequestHash:  requestHash,
+						ResponseCode: sql.NullInt32{Int32: http.StatusOK, Valid: true},
+						ResponseBody: []byte(`{"replayed":true}`),
+					}, nil)
+			},
+			expectedCalls: 0,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `{"replayed":true}`, recorder.Body.String())
+			},
+		},
+		{
+			name:           "DifferentRequest",
+			idempotencyKey: key,
+			handlerStatus:  http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{Username: username, Key: key, RequestHash: "other"}, nil)
+			},
+			expectedCalls: 0,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			// The claim is never taken over, the first request may have committed before it stopped
+			name:           "ClaimedWithoutResponse",
+			idempotencyKey: key,
+			handlerStatus:  http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(idempotencyKey, nil)
+			},
+			expectedCalls: 0,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:           "FailedRequest",
+			idempotencyKey: key,
+			handlerStatus:  http.StatusInternalServerError,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(idempotencyKey, nil)
+				store.EXPECT().
+					DeleteIdempotencyKey(gomock.Any(), gomock.Eq(db.DeleteIdempotencyKeyParams{
+						Username: username,
+						Key:      key,
+						ClaimID:  claimID,
+					})).
+					Times(1)
+				store.EXPECT().UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedCalls: 1,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:           "InvalidKey",
+			idempotencyKey: "short",
+			handlerStatus:  http.StatusOK,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateIdempotencyKey(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedCalls: 0,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+
+			calls := 0
+			server.router.POST(
+				idempotentPath,
+				authMiddleware(server.tokenMaker, server.sessionCache),
+				idempotencyMiddleware(server.store),
+				func(ctx *gin.Context) {
+					calls++
+					ctx.JSON(tc.handlerStatus, gin.H{"handled": true})
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, idempotentPath, bytes.NewReader(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, username, rbac.DepositorRole, time.Minute)
+			if tc.idempotencyKey != "" {
+				request.Header.Set(idempotencyKeyHeader, tc.idempotencyKey)
+			}
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.expectedCalls, calls)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
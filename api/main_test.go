@@ -1,21 +1,30 @@
 package api
 
 import (
+	"context"
 	"os"
+	mockdb "simple-bank/db/mock"
 	db "simple-bank/db/sqlc"
 	"simple-bank/util"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
 func newTestServer(t *testing.T, store db.Store) *Server {
 	config := util.Config{
-		TokenSymmetricKey:   util.RandomString(32),
-		AccessTokenDuration: time.Minute,
+		TokenSymmetricKey:        util.RandomString(32),
+		AccessTokenDuration:      time.Minute,
+		DefaultAccountCurrencies: []string{util.USD, util.EUR},
+	}
+
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		stubActiveSessions(mockStore)
 	}
 
 	server, err := NewServer(config, store)
@@ -24,6 +33,20 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 	return server
 }
 
+// The auth middleware looks up the session of every access token.
+// Tests that need a blocked or missing session add their own expectation before this one.
+func stubActiveSessions(store *mockdb.MockStore) {
+	store.EXPECT().
+		GetSession(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, id uuid.UUID) (db.Session, error) {
+			return db.Session{
+				ID:        id,
+				ExpiresAt: time.Now().Add(time.Hour),
+			}, nil
+		})
+}
+
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"simple-bank/rbac"
+	"simple-bank/sessioncache"
 	"simple-bank/token"
 	"strings"
 
@@ -16,7 +18,7 @@ const (
 	authorizationPayloadKey = "authorization_payload"
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+func authMiddleware(tokenMaker token.Maker, sessionCache *sessioncache.Cache) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		if len(authorizationHeader) == 0 {
@@ -46,6 +48,19 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 			return
 		}
 
+		if !rbac.IsSupportedRole(payload.Role) {
+			err := fmt.Errorf("unsupported role: %q", payload.Role)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+
+		// The token stays valid until it expires, so reject it once its session is blocked
+		err = sessionCache.Check(ctx, payload.SessionID)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+
 		// This is Gin specific, not Go's standard context.
 		// Gin's context has a way to set a key-value pair in the context.
 		ctx.Set(authorizationPayloadKey, payload)
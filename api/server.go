@@ -3,6 +3,9 @@ package api
 import (
 	"fmt"
 	db "simple-bank/db/sqlc"
+	"simple-bank/fx"
+	"simple-bank/pagination"
+	"simple-bank/sessioncache"
 	"simple-bank/token"
 	"simple-bank/util"
 
@@ -13,9 +16,12 @@ import (
 
 // Server servers HTTP requests
 type Server struct {
-	config     util.Config
-	store      db.Store
-	tokenMaker token.Maker
+	config         util.Config
+	store          db.Store
+	tokenMaker     token.Maker
+	pageTokenMaker *pagination.Maker
+	sessionCache   *sessioncache.Cache
+	rateProvider   fx.RateProvider
 	// Router will send each API request to correct handler
 	router *gin.Engine
 }
@@ -28,14 +34,28 @@ func NewServer(config util.Config, store db.Store) (*Server, error) {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	pageTokenMaker, err := pagination.NewMaker(config.TokenSymmetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create page token maker: %w", err)
+	}
+
+	rateProvider, err := fx.NewProvider(config, store)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create rate provider: %w", err)
+	}
+
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:         config,
+		store:          store,
+		tokenMaker:     tokenMaker,
+		pageTokenMaker: pageTokenMaker,
+		sessionCache:   sessioncache.New(store, config.SessionCacheTTL),
+		rateProvider:   rateProvider,
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("page_size", validPageSize)
 	}
 
 	server.setupRouter()
@@ -50,17 +70,29 @@ func (server *Server) setupRouter() {
 	// Login
 	router.POST("/users/login", server.loginUser)
 	router.POST("/tokens/renew", server.renewAccessToken)
+	// Logout
+	router.POST("/users/logout", server.logoutUser)
 
-	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	authRoutes := router.Group("/").Use(
+		authMiddleware(server.tokenMaker, server.sessionCache),
+		accessMiddleware(routeAccessTable),
+	)
 
 	// Create account
-	authRoutes.POST("/accounts", server.createAccount)
+	authRoutes.POST("/accounts", idempotencyMiddleware(server.store), server.createAccount)
 	// Get an account
 	authRoutes.GET("/accounts/:id", server.getAccount)
 	// Get accounts
 	authRoutes.GET("/accounts", server.getAccounts)
+	// Account statement
+	authRoutes.GET("/accounts/:id/entries", server.listAccountEntries)
+	authRoutes.GET("/accounts/:id/transfers", server.listAccountTransfers)
 	// Transfer
-	authRoutes.POST("/transfers", server.createTransfer)
+	authRoutes.POST("/transfers", idempotencyMiddleware(server.store), server.createTransfer)
+	// Sessions of the logged in user
+	authRoutes.GET("/sessions", server.listSessions)
+	authRoutes.DELETE("/sessions/:id", server.revokeSession)
+	authRoutes.DELETE("/sessions", server.revokeAllSessions)
 
 	server.router = router
 }
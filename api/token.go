@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"simple-bank/audit"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -31,7 +32,7 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
-	session, err := server.store.GetSession(ctx, refreshPayload.ID)
+	session, err := server.store.GetSession(ctx, refreshPayload.SessionID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -41,13 +42,39 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
-	if session.IsBlocked {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("blocked session")))
+	if session.Username != refreshPayload.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("incorrect session user")))
 		return
 	}
 
-	if session.Username != refreshPayload.Username {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("incorrect session user")))
+	// Access tokens carry the same session id, so the token is checked before it can count as a reuse
+	if session.RefreshToken != req.RefreshToken {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("mismatched session token")))
+		return
+	}
+
+	// A replaced session means its refresh token was already rotated, so this one is a replay
+	if session.ReplacedBy.Valid {
+		err = server.store.BlockSessionFamily(ctx, session.FamilyID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		server.sessionCache.InvalidateUser(session.Username)
+
+		audit.SecurityEvent(audit.EventRefreshTokenReuse, session.Username).
+			Str("session_id", session.ID.String()).
+			Str("family_id", session.FamilyID.String()).
+			Str("user_agent", ctx.Request.UserAgent()).
+			Str("client_ip", ctx.ClientIP()).
+			Msg("refresh token reused, session family revoked")
+
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("refresh token reused")))
+		return
+	}
+
+	if session.IsBlocked {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errors.New("blocked session")))
 		return
 	}
 
@@ -56,8 +83,17 @@ func (server *Server) renewAccessToken(ctx *gin.Context) {
 		return
 	}
 
+	// The role is read again so a role change applies from the next renewal
+	user, err := server.store.GetUser(ctx, session.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
 		session.Username,
+		user.Role,
+		session.ID,
 		server.config.AccessTokenDuration,
 	)
 	if err != nil {
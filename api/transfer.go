@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"net/http"
 	db "simple-bank/db/sqlc"
+	"simple-bank/fx"
+	"simple-bank/pagination"
 	"simple-bank/token"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,6 +19,8 @@ type transferRequest struct {
 	ToAccountID   int64  `json:"to_account_id" binding:"required,min=1"`
 	Amount        int64  `json:"amount" binding:"required,min=1"`
 	Currency      string `json:"currency" binding:"required,currency"`
+	// Holds the transfer until the from account owner completes or cancels it
+	Pending bool `json:"pending"`
 }
 
 func (server *Server) createTransfer(ctx *gin.Context) {
@@ -37,26 +42,103 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	_, valid = server.validAccount(ctx, req.ToAccountID, req.Currency)
+	// The to account may be in another currency, the amount is converted then
+	toAccount, valid := server.findAccount(ctx, req.ToAccountID)
 	if !valid {
 		return
 	}
 
+	if db.IsHouseAccount(toAccount) {
+		err := errors.New("to account is a house account and can't receive transfers")
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Pending {
+		server.createPendingTransfer(ctx, req, fromAccount, toAccount)
+		return
+	}
+
 	arg := db.TransferTxParams{
 		FromAccountID: req.FromAccountID,
 		ToAccountID:   req.ToAccountID,
 		Amount:        req.Amount,
 	}
 
-	account, err := server.store.TransferTx(ctx, arg)
+	var result db.TransferTxResult
+	var err error
+	if toAccount.Currency == fromAccount.Currency {
+		result, err = server.store.TransferTx(ctx, arg)
+	} else {
+		result, err = server.crossCurrencyTransfer(ctx, arg, fromAccount.Currency, toAccount.Currency)
+	}
 	if err != nil {
+		if errors.Is(err, db.ErrInsufficientFunds) ||
+			errors.Is(err, fx.ErrRateNotFound) ||
+			errors.Is(err, fx.ErrConversionTooSmall) ||
+			errors.Is(err, fx.ErrConversionOverflows) {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrLimitExceeded) {
+			ctx.JSON(http.StatusTooManyRequests, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, result)
 }
 
-func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
+// Moves no money, the fee is worked out now but a cross-currency amount is converted on completion
+func (server *Server) createPendingTransfer(ctx *gin.Context, req transferRequest, fromAccount db.Account, toAccount db.Account) {
+	fee, err := server.store.GetTransferFee(ctx, fromAccount, req.Amount)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	transfer, err := server.store.CreatePendingTransfer(ctx, db.CreatePendingTransferParams{
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount,
+		Fee:           fee,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, db.TransferTxResult{
+		Transfer:    transfer,
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+	})
+}
+
+// Converts the amount with the current rate and moves it between accounts of different currencies
+func (server *Server) crossCurrencyTransfer(ctx *gin.Context, arg db.TransferTxParams, fromCurrency string, toCurrency string) (db.TransferTxResult, error) {
+	rate, err := server.rateProvider.GetRate(ctx, fromCurrency, toCurrency, time.Now())
+	if err != nil {
+		return db.TransferTxResult{}, err
+	}
+
+	toAmount, err := rate.Convert(arg.Amount)
+	if err != nil {
+		return db.TransferTxResult{}, err
+	}
+
+	return server.store.CrossCurrencyTransferTx(ctx, db.CrossCurrencyTransferTxParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		ToAmount:      toAmount,
+		ExchangeRate:  rate.Value,
+	})
+}
+
+func (server *Server) findAccount(ctx *gin.Context, accountID int64) (db.Account, bool) {
 	account, err := server.store.GetAccount(ctx, accountID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -68,6 +150,15 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 		return account, false
 	}
 
+	return account, true
+}
+
+func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency string) (db.Account, bool) {
+	account, valid := server.findAccount(ctx, accountID)
+	if !valid {
+		return account, false
+	}
+
 	if account.Currency != currency {
 		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", accountID, account.Currency, currency)
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
@@ -76,3 +167,53 @@ func (server *Server) validAccount(ctx *gin.Context, accountID int64, currency s
 
 	return account, true
 }
+
+type listAccountTransfersResponse struct {
+	Transfers []db.Transfer `json:"transfers"`
+	// Empty on the last page
+	NextPageToken string `json:"next_page_token"`
+}
+
+func (server *Server) listAccountTransfers(ctx *gin.Context) {
+	req, accountID, ok := server.bindAccountHistoryRequest(ctx)
+	if !ok {
+		return
+	}
+
+	scope := pagination.TransfersScope(accountID)
+	afterID, err := server.pageTokenMaker.VerifyToken(req.PageToken, scope)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListTransfersParams{
+		AccountID: accountID,
+		AfterID:   afterID,
+		StartTime: nullTime(req.StartTime),
+		EndTime:   nullTime(req.EndTime),
+		Direction: sql.NullString{String: req.Direction, Valid: req.Direction != ""},
+		MinAmount: nullInt64(req.MinAmount),
+		MaxAmount: nullInt64(req.MaxAmount),
+		Limit:     pagination.Limit(req.PageSize),
+	}
+
+	transfers, err := server.store.ListTransfers(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	transfers, hasNextPage := pagination.Page(transfers, req.PageSize)
+
+	rsp := listAccountTransfersResponse{Transfers: transfers}
+	if hasNextPage {
+		rsp.NextPageToken, err = server.pageTokenMaker.CreateToken(scope, transfers[len(transfers)-1].ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
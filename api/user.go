@@ -2,9 +2,11 @@ package api
 
 import (
 	"database/sql"
+	"fmt"
 	"net/http"
 	db "simple-bank/db/sqlc"
 	"simple-bank/util"
+	"simple-bank/val"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -23,6 +25,8 @@ type userResponse struct {
 	Username          string    `json:"username"`
 	FullName          string    `json:"full_name"`
 	Email             string    `json:"email"`
+	Role              string    `json:"role"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
 }
@@ -32,6 +36,8 @@ func newUserResponse(user db.User) userResponse {
 		Username:          user.Username,
 		FullName:          user.FullName,
 		Email:             user.Email,
+		Role:              user.Role,
+		IsEmailVerified:   user.IsEmailVerified,
 		PasswordChangedAt: user.PasswordChangedAt,
 		CreatedAt:         user.CreatedAt,
 	}
@@ -46,20 +52,32 @@ func (server *Server) createUser(ctx *gin.Context) {
 		return
 	}
 
+	if val.IsReservedUsername(req.Username) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("username %q is reserved", req.Username)))
+		return
+	}
+
 	hashedPassword, err := util.HashPassword(req.Password)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	arg := db.CreateUserParams{
-		Username:       req.Username,
-		HashedPassword: hashedPassword,
-		FullName:       req.FullName,
-		Email:          req.Email,
+	arg := db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:       req.Username,
+			HashedPassword: hashedPassword,
+			FullName:       req.FullName,
+			Email:          req.Email,
+		},
+		// A failure here rolls back the user, so signup never leaves a user without accounts
+		AfterCreate: func(q db.Querier, user db.User) error {
+			_, err := db.CreateDefaultAccounts(ctx, q, user.Username, server.config.DefaultAccountCurrencies)
+			return err
+		},
 	}
 
-	user, err := server.store.CreateUser(ctx, arg)
+	txResult, err := server.store.CreateUserTx(ctx, arg)
 	if err != nil {
 		if pgErr, ok := err.(*pq.Error); ok {
 			switch pgErr.Code.Name() {
@@ -69,9 +87,10 @@ func (server *Server) createUser(ctx *gin.Context) {
 			}
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	rsp := newUserResponse(user)
+	rsp := newUserResponse(txResult.User)
 	ctx.JSON(http.StatusOK, rsp)
 }
 
@@ -112,8 +131,13 @@ func (server *Server) loginUser(ctx *gin.Context) {
 		return
 	}
 
+	// Both tokens are bound to the session created below
+	sessionID := uuid.New()
+
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
+		sessionID,
 		server.config.AccessTokenDuration,
 	)
 	if err != nil {
@@ -123,6 +147,8 @@ func (server *Server) loginUser(ctx *gin.Context) {
 
 	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
+		sessionID,
 		server.config.RefreshTokenDuration,
 	)
 	if err != nil {
@@ -131,13 +157,15 @@ func (server *Server) loginUser(ctx *gin.Context) {
 	}
 
 	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
+		ID:           sessionID,
 		Username:     user.Username,
 		RefreshToken: refreshToken,
 		UserAgent:    ctx.Request.UserAgent(),
 		ClientIp:     ctx.ClientIP(),
 		IsBlocked:    false,
 		ExpiresAt:    refreshPayload.ExpiredAt,
+		// A login starts a new session family
+		FamilyID: sessionID,
 	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"simple-bank/pagination"
 	"simple-bank/util"
 
 	"github.com/go-playground/validator/v10"
@@ -13,3 +14,10 @@ var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
 	}
 	return false
 }
+
+var validPageSize validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if pageSize, ok := fieldLevel.Field().Interface().(int32); ok {
+		return pageSize >= 1 && pageSize <= pagination.MaxPageSize
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Security related event types, logged under the "audit_event" field so they can be filtered from request logs
+const (
+	EventRefreshTokenReuse = "refresh_token_reuse"
+)
+
+// Starts a security audit log entry, the caller adds more fields and sends it with `Msg`
+func SecurityEvent(eventType string, username string) *zerolog.Event {
+	return log.Warn().
+		Str("audit_event", eventType).
+		Str("username", username)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	db "simple-bank/db/sqlc"
+	"simple-bank/ledger"
+	"simple-bank/util"
+
+	_ "github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+// Exit codes, so a nightly job can tell a broken ledger from a failed run
+const (
+	exitDiscrepancies = 1
+	exitFailure       = 2
+)
+
+// Checks the ledger invariants and prints the report as JSON on stdout.
+// Run it from the repository root so `app.env` is found: `go run ./cmd/reconcile`
+func main() {
+	config, err := util.LoadConfig(".")
+	if err != nil {
+		log.Error().Err(err).Msg("cannot load config")
+		os.Exit(exitFailure)
+	}
+
+	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	if err != nil {
+		log.Error().Err(err).Msg("cannot connect to db")
+		os.Exit(exitFailure)
+	}
+	defer conn.Close()
+
+	report, err := ledger.Reconcile(context.Background(), db.New(conn))
+	if err != nil {
+		log.Error().Err(err).Msg("cannot reconcile ledger")
+		os.Exit(exitFailure)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Error().Err(err).Msg("cannot write report")
+		os.Exit(exitFailure)
+	}
+
+	if !report.OK {
+		log.Warn().
+			Int("accounts", len(report.Accounts)).
+			Int("transfers", len(report.Transfers)).
+			Int("currencies", len(report.Currencies)).
+			Msg("ledger discrepancies found")
+		os.Exit(exitDiscrepancies)
+	}
+}
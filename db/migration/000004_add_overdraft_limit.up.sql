@@ -0,0 +1,5 @@
+-- How far below zero the balance of an account is allowed to go.
+-- The default of 0 means the account can never be overdrawn.
+ALTER TABLE "accounts" ADD COLUMN "overdraft_limit" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "accounts" ADD CONSTRAINT "overdraft_limit_non_negative" CHECK ("overdraft_limit" >= 0);
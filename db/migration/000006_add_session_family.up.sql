@@ -0,0 +1,13 @@
+-- Every session created by renewing a refresh token belongs to the family of the login session
+ALTER TABLE "sessions" ADD COLUMN "family_id" uuid;
+
+UPDATE "sessions" SET "family_id" = "id";
+
+ALTER TABLE "sessions" ALTER COLUMN "family_id" SET NOT NULL;
+
+-- The session that replaced this one when its refresh token was rotated
+ALTER TABLE "sessions" ADD COLUMN "replaced_by" uuid;
+
+ALTER TABLE "sessions" ADD FOREIGN KEY ("replaced_by") REFERENCES "sessions" ("id");
+
+CREATE INDEX ON "sessions" ("family_id");
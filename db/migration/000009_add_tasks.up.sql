@@ -0,0 +1,23 @@
+-- Queue of background tasks, claimed by the workers with `FOR UPDATE SKIP LOCKED`.
+-- A task is pending until a worker claims it, then running until it's completed,
+-- scheduled again after a failure, or dead once it ran out of attempts.
+CREATE TABLE "tasks" (
+  "id" bigserial PRIMARY KEY,
+  "type" varchar NOT NULL,
+  "payload" jsonb NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "max_attempts" int NOT NULL DEFAULT 10,
+  "last_error" varchar NOT NULL DEFAULT '',
+  "run_at" timestamptz NOT NULL DEFAULT (now()),
+  -- A running task whose lease has expired is claimed again, eg: the worker crashed
+  "locked_until" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "tasks" ADD CONSTRAINT "tasks_status_check" CHECK ("status" IN ('pending', 'running', 'completed', 'dead'));
+
+CREATE INDEX ON "tasks" ("run_at") WHERE "status" = 'pending';
+
+CREATE INDEX ON "tasks" ("locked_until") WHERE "status" = 'running';
@@ -0,0 +1,3 @@
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" = 'system');
+DELETE FROM "accounts" WHERE "owner" = 'system';
+DELETE FROM "users" WHERE "username" = 'system';
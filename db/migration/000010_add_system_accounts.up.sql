@@ -0,0 +1,13 @@
+-- Owns the per-currency cash accounts that deposits and withdrawals are booked against.
+-- The password hash is not a bcrypt hash, so nobody can log in as this user.
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email", "is_email_verified")
+VALUES ('system', '!', 'Simple Bank', 'system@simplebank.com', true)
+ON CONFLICT ("username") DO NOTHING;
+
+-- A customer who signed up as `system` before the name was reserved would own the cash accounts
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM "users" WHERE "username" = 'system' AND "hashed_password" <> '!') THEN
+    RAISE EXCEPTION 'the username system is taken by a customer, rename that user before running this migration';
+  END IF;
+END $$;
@@ -0,0 +1,16 @@
+-- Links the two entries of a transfer to it, deposits and withdrawals have no transfer
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "entries" ("transfer_id");
+
+-- `TransferTx` inserts a transfer and its entries in one transaction, so they share `now()`
+UPDATE "entries" e
+SET "transfer_id" = t."id"
+FROM "transfers" t
+WHERE
+  e."transfer_id" IS NULL AND
+  e."created_at" = t."created_at" AND
+  ((e."account_id" = t."from_account_id" AND e."amount" = -t."amount") OR
+   (e."account_id" = t."to_account_id" AND e."amount" = t."amount"));
@@ -0,0 +1,8 @@
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "exchange_rate";
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "to_amount";
+ALTER TABLE IF EXISTS "entries" DROP COLUMN IF EXISTS "from_amount";
+
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "exchange_rate";
+ALTER TABLE IF EXISTS "transfers" DROP COLUMN IF EXISTS "to_amount";
+
+DROP TABLE IF EXISTS "rates";
@@ -0,0 +1,20 @@
+-- Exchange rates, one unit of `base_currency` is worth `rate` units of `quote_currency`.
+-- The rate of a pair at a point in time is the latest one that is already effective.
+CREATE TABLE "rates" (
+  "id" bigserial PRIMARY KEY,
+  "base_currency" varchar NOT NULL,
+  "quote_currency" varchar NOT NULL,
+  "rate" numeric NOT NULL CHECK ("rate" > 0),
+  "effective_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "rates" ("base_currency", "quote_currency", "effective_at");
+
+-- Only set for cross-currency transfers, `amount` is always in the currency of the from account
+ALTER TABLE "transfers" ADD COLUMN "to_amount" bigint;
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" numeric;
+
+ALTER TABLE "entries" ADD COLUMN "from_amount" bigint;
+ALTER TABLE "entries" ADD COLUMN "to_amount" bigint;
+ALTER TABLE "entries" ADD COLUMN "exchange_rate" numeric;
@@ -0,0 +1,20 @@
+-- ISO 4217 currencies, amounts of a currency are stored in its minor units
+CREATE TABLE "currencies" (
+  "code" varchar(3) PRIMARY KEY CHECK ("code" ~ '^[A-Z]{3}$'),
+  "minor_units" integer NOT NULL CHECK ("minor_units" BETWEEN 0 AND 4),
+  "enabled" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+INSERT INTO "currencies" ("code", "minor_units", "enabled") VALUES
+  ('USD', 2, true),
+  ('EUR', 2, true),
+  ('INR', 2, true),
+  ('CAD', 2, true),
+  ('GBP', 2, false),
+  ('CHF', 2, false),
+  ('AUD', 2, false),
+  ('JPY', 0, false);
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
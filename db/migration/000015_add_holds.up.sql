@@ -0,0 +1,27 @@
+-- Funds reserved by active holds, the available balance is "balance" - "held_amount"
+ALTER TABLE "accounts" ADD COLUMN "held_amount" bigint NOT NULL DEFAULT 0 CHECK ("held_amount" >= 0);
+
+-- A two-phase authorization of a transfer from "account_id" to "to_account_id".
+-- The amount is reserved until the hold is captured, released or expires.
+CREATE TABLE "holds" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL CHECK ("amount" > 0),
+  "status" varchar NOT NULL DEFAULT 'active',
+  -- The settlement, set once the hold is captured
+  "transfer_id" bigint,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "holds_status_check" CHECK ("status" IN ('active', 'captured', 'released', 'expired'))
+);
+
+ALTER TABLE "holds" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "holds" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "holds" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "holds" ("account_id");
+
+-- Expiry only looks for active holds
+CREATE INDEX ON "holds" ("expires_at") WHERE "status" = 'active';
@@ -0,0 +1,6 @@
+-- Set on a reversal, the transfer it refunds. A transfer can be refunded in parts up to its amount.
+ALTER TABLE "transfers" ADD COLUMN "reversed_transfer_id" bigint;
+
+ALTER TABLE "transfers" ADD FOREIGN KEY ("reversed_transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "transfers" ("reversed_transfer_id");
@@ -0,0 +1,6 @@
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS "transfers_amount_check";
+ALTER TABLE "transfers" ALTER COLUMN "amount" DROP NOT NULL;
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "status";
+
+DROP TYPE IF EXISTS "transfer_status";
@@ -0,0 +1,52 @@
+CREATE TYPE "transfer_status" AS ENUM (
+  'pending',
+  'completed',
+  'failed',
+  'reversed',
+  'cancelled'
+);
+
+-- Only completed and reversed transfers have entries, a pending transfer moves no money until it is completed
+ALTER TABLE "transfers" ADD COLUMN "status" transfer_status NOT NULL DEFAULT 'completed';
+
+-- The application always sets the amount, a transfer without one gets it back from its debit entry.
+-- `add_entry_transfer_id` matched entries on the amount, so the entries of these transfers may not be linked yet.
+UPDATE "transfers" t
+SET "amount" = -e."amount"
+FROM "entries" e
+WHERE
+  t."amount" IS NULL AND
+  e."account_id" = t."from_account_id" AND
+  e."amount" < 0 AND
+  (e."transfer_id" = t."id" OR (e."transfer_id" IS NULL AND e."created_at" = t."created_at"));
+
+UPDATE "entries" e
+SET "transfer_id" = t."id"
+FROM "transfers" t
+WHERE
+  e."transfer_id" IS NULL AND
+  e."created_at" = t."created_at" AND
+  ((e."account_id" = t."from_account_id" AND e."amount" = -t."amount") OR
+   (e."account_id" = t."to_account_id" AND e."amount" = t."amount"));
+
+-- A transfer that still has no amount can't be recovered, it is left for someone to look at instead of being deleted
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM "transfers" WHERE "amount" IS NULL) THEN
+    RAISE EXCEPTION 'transfers without an amount or a debit entry to backfill it from, fix them before running this migration';
+  END IF;
+END $$;
+
+-- Transfers that were refunded in full
+UPDATE "transfers" t
+SET "status" = 'reversed'
+WHERE t."amount" <= (
+  SELECT COALESCE(SUM(COALESCE(r."to_amount", r."amount")), 0)
+  FROM "transfers" r
+  WHERE r."reversed_transfer_id" = t."id"
+);
+
+ALTER TABLE "transfers" ALTER COLUMN "amount" SET NOT NULL;
+ALTER TABLE "transfers" ADD CONSTRAINT "transfers_amount_check" CHECK ("amount" > 0);
+
+CREATE INDEX ON "transfers" ("status") WHERE "status" = 'pending';
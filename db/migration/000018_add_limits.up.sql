@@ -0,0 +1,22 @@
+-- Outgoing transfer limits in the minor units of the currency, 0 means no limit.
+-- A row without an account applies to every account of the currency, an account row overrides it.
+-- A NULL limit falls back to the currency row, then to the defaults of the config.
+CREATE TABLE "limits" (
+  "id" bigserial PRIMARY KEY,
+  "currency" varchar NOT NULL,
+  "account_id" bigint,
+  "per_transaction" bigint CHECK ("per_transaction" >= 0),
+  "daily" bigint CHECK ("daily" >= 0),
+  "monthly" bigint CHECK ("monthly" >= 0),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "limits" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+ALTER TABLE "limits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+-- One row per currency and one per account
+CREATE UNIQUE INDEX ON "limits" ("currency", (COALESCE("account_id", 0)));
+
+-- The daily and monthly totals sum the outgoing transfer entries of an account
+CREATE INDEX ON "entries" ("account_id", "created_at") WHERE "transfer_id" IS NOT NULL AND "amount" < 0;
@@ -0,0 +1,11 @@
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "fee";
+
+DROP TABLE IF EXISTS "fee_rules";
+
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "id" FROM "accounts" WHERE "owner" = 'fees');
+DELETE FROM "accounts" WHERE "owner" = 'fees';
+DELETE FROM "users" WHERE "username" = 'fees';
+
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "type";
+
+DROP TYPE IF EXISTS "account_type";
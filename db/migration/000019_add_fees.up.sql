@@ -0,0 +1,46 @@
+CREATE TYPE "account_type" AS ENUM (
+  'personal',
+  'business'
+);
+
+ALTER TABLE "accounts" ADD COLUMN "type" account_type NOT NULL DEFAULT 'personal';
+
+-- Owns the per-currency revenue accounts that transfer fees are credited to.
+-- The password hash is not a bcrypt hash, so nobody can log in as this user.
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email", "is_email_verified")
+VALUES ('fees', '!', 'Simple Bank Fees', 'fees@simplebank.com', true)
+ON CONFLICT ("username") DO NOTHING;
+
+-- A customer who signed up as `fees` before the name was reserved would own the fee revenue
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM "users" WHERE "username" = 'fees' AND "hashed_password" <> '!') THEN
+    RAISE EXCEPTION 'the username fees is taken by a customer, rename that user before running this migration';
+  END IF;
+END $$;
+
+-- Fees in the currency of the from account, on top of the amount of the transfer.
+-- A rule without a currency or an account type applies to all of them, the most specific rule wins.
+-- The fee is `flat_fee` plus `rate_bps` hundredths of a percent of the amount,
+-- then raised to `min_fee` and capped at `max_fee`, 0 means no minimum or maximum.
+CREATE TABLE "fee_rules" (
+  "id" bigserial PRIMARY KEY,
+  "currency" varchar,
+  "account_type" account_type,
+  "flat_fee" bigint NOT NULL DEFAULT 0 CHECK ("flat_fee" >= 0),
+  "rate_bps" bigint NOT NULL DEFAULT 0 CHECK ("rate_bps" >= 0 AND "rate_bps" <= 10000),
+  "min_fee" bigint NOT NULL DEFAULT 0 CHECK ("min_fee" >= 0),
+  "max_fee" bigint NOT NULL DEFAULT 0 CHECK ("max_fee" >= 0),
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CHECK ("max_fee" = 0 OR "max_fee" >= "min_fee")
+);
+
+ALTER TABLE "fee_rules" ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+-- One rule per currency and account type, with and without an account type
+CREATE UNIQUE INDEX ON "fee_rules" ((COALESCE("currency", '')), "account_type") WHERE "account_type" IS NOT NULL;
+CREATE UNIQUE INDEX ON "fee_rules" ((COALESCE("currency", ''))) WHERE "account_type" IS NULL;
+
+-- The fee charged by the transfer, its entries credit the fee account of the currency
+ALTER TABLE "transfers" ADD COLUMN "fee" bigint NOT NULL DEFAULT 0 CHECK ("fee" >= 0);
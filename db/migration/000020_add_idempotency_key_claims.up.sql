@@ -0,0 +1,4 @@
+-- Identifies the request that claimed a key, only that request may save its response or release it.
+-- A claimed key is never handed to another request, since the first one may have committed already.
+-- Keys claimed before this runs have no claim and are left as they are.
+ALTER TABLE "idempotency_keys" ADD COLUMN "claim_id" uuid;
@@ -0,0 +1,3 @@
+-- The fee a capture of the whole hold is charged, reserved with the amount so the capture can always pay it.
+-- Holds placed before this reserved no fee.
+ALTER TABLE "holds" ADD COLUMN "fee" bigint NOT NULL DEFAULT 0 CHECK ("fee" >= 0);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "transfers_from_account_id_created_at_idx";
+
+CREATE INDEX ON "entries" ("account_id", "created_at") WHERE "transfer_id" IS NOT NULL AND "amount" < 0;
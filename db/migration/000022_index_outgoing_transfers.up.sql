@@ -0,0 +1,4 @@
+-- The daily and monthly totals sum the outgoing transfers of an account instead of its entries
+DROP INDEX IF EXISTS "entries_account_id_created_at_idx";
+
+CREATE INDEX ON "transfers" ("from_account_id", "created_at") WHERE "reversed_transfer_id" IS NULL;
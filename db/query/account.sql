@@ -1,10 +1,12 @@
 -- name: CreateAccount :one
+-- A personal account unless the type is set
 INSERT INTO accounts (
   owner,
   balance,
-  currency
+  currency,
+  type
 ) VALUES (
-  $1, $2, $3
+  sqlc.arg(owner), sqlc.arg(balance), sqlc.arg(currency), COALESCE(sqlc.narg(type)::account_type, 'personal')
 )
 RETURNING *;
 
@@ -17,18 +19,48 @@ SELECT * FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE;
 
+-- name: GetOrCreateSystemAccount :one
+-- The cash account of the currency, created on first use.
+-- The no-op update makes the conflicting row come back locked, like `GetAccountForUpdate`.
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency
+) VALUES (
+  'system', 0, $1
+)
+ON CONFLICT (owner, currency) DO UPDATE SET owner = EXCLUDED.owner
+RETURNING *;
+
+-- name: GetOrCreateFeeAccount :one
+-- The fee revenue account of the currency, created on first use and returned locked
+INSERT INTO accounts (
+  owner,
+  balance,
+  currency
+) VALUES (
+  'fees', 0, $1
+)
+ON CONFLICT (owner, currency) DO UPDATE SET owner = EXCLUDED.owner
+RETURNING *;
+
 -- name: ListAccounts :many
+-- Keyset pagination, the next page starts after the last id of the previous page
 SELECT * FROM accounts
-WHERE owner = $1
+WHERE owner = sqlc.arg(owner) AND id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg('limit');
 
 -- name: UpdateAccount :one
 UPDATE accounts SET balance = $2
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts SET overdraft_limit = $2
+WHERE id = $1
+RETURNING *;
+
 -- name: AddAccountBalance :one
 UPDATE accounts
 SET balance = balance + sqlc.arg(amount)
@@ -38,3 +70,9 @@ RETURNING *;
 -- name: DeleteAccount :exec
 DELETE FROM accounts
 WHERE id = $1;
+
+-- name: AddAccountHeldAmount :one
+UPDATE accounts
+SET held_amount = held_amount + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
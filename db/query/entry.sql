@@ -1,9 +1,13 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id,
+  from_amount,
+  to_amount,
+  exchange_rate
 ) VALUES (
-  $1, $2
+  $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -11,8 +15,19 @@ SELECT * FROM entries
 WHERE id = $1 LIMIT 1;
 
 -- name: ListEntries :many
+-- Keyset pagination, the next page starts after the last id of the previous page.
+-- Filters are optional, a NULL filter matches every entry.
+-- The amount range is compared with the absolute amount, so it works for both directions.
 SELECT * FROM entries
-WHERE account_id = $1
+WHERE
+  account_id = sqlc.arg(account_id) AND
+  id > sqlc.arg(after_id) AND
+  (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time)::timestamptz) AND
+  (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time)::timestamptz) AND
+  (sqlc.narg(direction)::varchar IS NULL OR
+    (sqlc.narg(direction)::varchar = 'incoming' AND amount > 0) OR
+    (sqlc.narg(direction)::varchar = 'outgoing' AND amount < 0)) AND
+  (sqlc.narg(min_amount)::bigint IS NULL OR abs(amount) >= sqlc.narg(min_amount)::bigint) AND
+  (sqlc.narg(max_amount)::bigint IS NULL OR abs(amount) <= sqlc.narg(max_amount)::bigint)
 ORDER BY id
-LIMIT $2
-OFFSET $3;
+LIMIT sqlc.arg('limit');
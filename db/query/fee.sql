@@ -0,0 +1,24 @@
+-- name: CreateFeeRule :one
+INSERT INTO fee_rules (
+  currency,
+  account_type,
+  flat_fee,
+  rate_bps,
+  min_fee,
+  max_fee
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetFeeRule :one
+-- The most specific rule for the currency and account type, a currency rule wins over an account type rule
+SELECT * FROM fee_rules
+WHERE
+  (currency IS NULL OR currency = sqlc.arg(currency)::varchar) AND
+  (account_type IS NULL OR account_type = sqlc.arg(account_type)::account_type)
+ORDER BY currency IS NULL, account_type IS NULL
+LIMIT 1;
+
+-- name: DeleteFeeRule :exec
+DELETE FROM fee_rules
+WHERE id = $1;
@@ -0,0 +1,41 @@
+-- name: CreateHold :one
+INSERT INTO holds (
+  account_id,
+  to_account_id,
+  amount,
+  fee,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetHold :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1;
+
+-- name: GetHoldForUpdate :one
+SELECT * FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpdateHoldStatus :one
+UPDATE holds
+SET
+  status = sqlc.arg(status),
+  transfer_id = sqlc.narg(transfer_id),
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListLapsedHoldsForUpdate :many
+-- Locks a batch of active holds past their expiry, holds locked by a capture or release are skipped
+SELECT * FROM holds
+WHERE status = 'active' AND expires_at <= now()
+ORDER BY id
+LIMIT sqlc.arg('limit')
+FOR NO KEY UPDATE SKIP LOCKED;
+
+-- name: ExpireHolds :exec
+UPDATE holds
+SET status = 'expired', updated_at = now()
+WHERE id = ANY(sqlc.arg(ids)::bigint[]);
@@ -0,0 +1,40 @@
+-- name: CreateIdempotencyKey :one
+-- Claims the key, no row comes back if it was already claimed.
+-- A claim is never taken over: a request that didn't finish may still have committed,
+-- so its retries are rejected until the key is released or its response is saved.
+INSERT INTO idempotency_keys (
+  username,
+  key,
+  request_hash,
+  claim_id
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (username, key) DO NOTHING
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE username = $1 AND key = $2 LIMIT 1;
+
+-- name: UpdateIdempotencyKeyResponse :one
+-- Saves the response, no row comes back unless the claim is still held by the request.
+UPDATE idempotency_keys
+SET
+  response_code = sqlc.arg(response_code),
+  response_body = sqlc.arg(response_body)
+WHERE
+  username = sqlc.arg(username) AND
+  key = sqlc.arg(key) AND
+  claim_id = sqlc.arg(claim_id) AND
+  response_code IS NULL
+RETURNING *;
+
+-- name: DeleteIdempotencyKey :exec
+-- Releases the key of a failed request, only while the claim is still held by it.
+DELETE FROM idempotency_keys
+WHERE
+  username = $1 AND
+  key = $2 AND
+  claim_id = $3 AND
+  response_code IS NULL;
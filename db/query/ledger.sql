@@ -0,0 +1,49 @@
+-- name: ListBalanceMismatches :many
+-- Accounts whose balance is not the sum of their entries
+SELECT
+  a.id,
+  a.owner,
+  a.currency,
+  a.balance,
+  COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id;
+
+-- name: ListUnbalancedTransfers :many
+-- Transfers that don't have exactly one debit entry on the from account and one credit entry on the to account.
+-- A cross-currency transfer also has one entry on the system account of each currency,
+-- and a transfer with a fee has one more debit on the from account and a credit on the fee account.
+-- Transfers that were never completed must have no entries.
+SELECT
+  t.id,
+  t.from_account_id,
+  t.to_account_id,
+  t.amount,
+  COUNT(e.id) AS entry_count,
+  COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM transfers t
+LEFT JOIN entries e ON e.transfer_id = t.id
+GROUP BY t.id
+HAVING
+  (t.status NOT IN ('completed', 'reversed') AND COUNT(e.id) <> 0) OR
+  (t.status IN ('completed', 'reversed') AND (
+    COUNT(e.id) <> (CASE WHEN t.exchange_rate IS NULL THEN 2 ELSE 4 END) + (CASE WHEN t.fee > 0 THEN 2 ELSE 0 END) OR
+    COUNT(e.id) FILTER (WHERE e.account_id = t.from_account_id) <> (CASE WHEN t.fee > 0 THEN 2 ELSE 1 END) OR
+    COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.from_account_id), 0) <> -(t.amount + t.fee) OR
+    COUNT(e.id) FILTER (WHERE e.account_id = t.to_account_id AND e.amount = COALESCE(t.to_amount, t.amount)) <> 1
+  ))
+ORDER BY t.id;
+
+-- name: ListCurrencyImbalances :many
+-- Currencies whose entries don't net to zero
+SELECT
+  a.currency,
+  SUM(e.amount)::bigint AS entries_total
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+GROUP BY a.currency
+HAVING SUM(e.amount) <> 0
+ORDER BY a.currency;
@@ -0,0 +1,35 @@
+-- name: UpsertLimit :one
+INSERT INTO limits (
+  currency,
+  account_id,
+  per_transaction,
+  daily,
+  monthly
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (currency, (COALESCE(account_id, 0))) DO UPDATE SET
+  per_transaction = EXCLUDED.per_transaction,
+  daily = EXCLUDED.daily,
+  monthly = EXCLUDED.monthly,
+  updated_at = now()
+RETURNING *;
+
+-- name: ListAccountLimits :many
+-- The limits of the currency and of the account, the account row comes last so it overrides the currency row
+SELECT * FROM limits
+WHERE currency = sqlc.arg(currency) AND (account_id IS NULL OR account_id = sqlc.arg(account_id))
+ORDER BY account_id NULLS FIRST;
+
+-- name: GetOutgoingTotals :one
+-- What the account transferred out since the start of the day and of the month.
+-- Pending transfers count as they will move the money, fees and reversals paid out of the account don't.
+SELECT
+  COALESCE(SUM(amount) FILTER (WHERE created_at >= sqlc.arg(day_start)), 0)::bigint AS daily_total,
+  COALESCE(SUM(amount), 0)::bigint AS monthly_total
+FROM transfers
+WHERE
+  from_account_id = sqlc.arg(account_id) AND
+  status IN ('completed', 'pending') AND
+  reversed_transfer_id IS NULL AND
+  created_at >= sqlc.arg(month_start);
@@ -0,0 +1,19 @@
+-- name: CreateRate :one
+INSERT INTO rates (
+  base_currency,
+  quote_currency,
+  rate,
+  effective_at
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetEffectiveRate :one
+-- The latest rate of the pair that is effective at the given time
+SELECT * FROM rates
+WHERE
+  base_currency = sqlc.arg(base_currency) AND
+  quote_currency = sqlc.arg(quote_currency) AND
+  effective_at <= sqlc.arg(at)
+ORDER BY effective_at DESC
+LIMIT 1;
@@ -0,0 +1,67 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  cron_expression,
+  interval_seconds,
+  next_run_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: ListScheduledTransfers :many
+-- Cancelled scheduled transfers are left out.
+-- Keyset pagination, the next page starts after the last id of the previous page
+SELECT * FROM scheduled_transfers
+WHERE owner = sqlc.arg(owner) AND status <> 'cancelled' AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: UpdateScheduledTransfer :one
+-- Completed and cancelled scheduled transfers are final, no row comes back for them
+UPDATE scheduled_transfers
+SET
+  amount = COALESCE(sqlc.narg(amount), amount),
+  status = COALESCE(sqlc.narg(status), status),
+  next_run_at = COALESCE(sqlc.narg(next_run_at), next_run_at),
+  updated_at = now()
+WHERE id = sqlc.arg(id) AND status IN ('active', 'paused')
+RETURNING *;
+
+-- name: GetScheduledTransferForUpdate :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ClaimDueScheduledTransfer :one
+-- Locks the most overdue active scheduled transfer, rows locked by other schedulers are skipped
+SELECT * FROM scheduled_transfers
+WHERE status = 'active' AND next_run_at <= now()
+ORDER BY next_run_at
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: CreateScheduledTransferRun :one
+INSERT INTO scheduled_transfer_runs (
+  scheduled_transfer_id,
+  scheduled_for,
+  status,
+  transfer_id,
+  error
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListScheduledTransferRuns :many
+-- Newest first
+SELECT * FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = $1
+ORDER BY scheduled_for DESC
+LIMIT $2;
@@ -6,12 +6,44 @@ INSERT INTO sessions (
   user_agent,
   client_ip,
   is_blocked,
-  expires_at
+  expires_at,
+  family_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
+  $1, $2, $3, $4, $5, $6, $7, $8
 )
 RETURNING *;
 
 -- name: GetSession :one
 SELECT * FROM sessions
 WHERE id = $1 LIMIT 1;
+
+-- name: ReplaceSession :one
+-- Only an active session can be replaced, so no row is returned when it is already blocked or replaced
+UPDATE sessions
+SET
+  is_blocked = true,
+  replaced_by = sqlc.arg(replaced_by)
+WHERE id = sqlc.arg(id) AND is_blocked = false AND replaced_by IS NULL
+RETURNING *;
+
+-- name: BlockSessionFamily :exec
+UPDATE sessions
+SET is_blocked = true
+WHERE family_id = $1;
+
+-- name: ListActiveSessions :many
+SELECT * FROM sessions
+WHERE username = $1 AND is_blocked = false AND expires_at > now()
+ORDER BY created_at DESC;
+
+-- name: BlockUserSession :one
+-- Scoped to the user so a session of another user is reported as not found
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1 AND username = $2
+RETURNING *;
+
+-- name: BlockUserSessions :execrows
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1 AND is_blocked = false;
@@ -0,0 +1,60 @@
+-- name: CreateTask :one
+INSERT INTO tasks (
+  type,
+  payload,
+  max_attempts,
+  run_at
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetTask :one
+SELECT * FROM tasks
+WHERE id = $1 LIMIT 1;
+
+-- name: ClaimTask :one
+-- Locks the next due task, tasks locked by other workers are skipped instead of waited for
+UPDATE tasks
+SET
+  status = 'running',
+  attempts = attempts + 1,
+  locked_until = sqlc.arg(locked_until),
+  updated_at = now()
+WHERE id = (
+  SELECT id FROM tasks
+  WHERE
+    (status = 'pending' AND run_at <= now())
+    OR (status = 'running' AND locked_until < now())
+  ORDER BY run_at
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteTask :exec
+UPDATE tasks
+SET
+  status = 'completed',
+  locked_until = NULL,
+  updated_at = now()
+WHERE id = $1;
+
+-- name: RetryTask :exec
+UPDATE tasks
+SET
+  status = 'pending',
+  run_at = sqlc.arg(run_at),
+  last_error = sqlc.arg(last_error),
+  locked_until = NULL,
+  updated_at = now()
+WHERE id = sqlc.arg(id);
+
+-- name: DeadLetterTask :exec
+UPDATE tasks
+SET
+  status = 'dead',
+  last_error = sqlc.arg(last_error),
+  locked_until = NULL,
+  updated_at = now()
+WHERE id = sqlc.arg(id);
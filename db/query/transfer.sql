@@ -2,20 +2,69 @@
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  to_amount,
+  exchange_rate,
+  reversed_transfer_id,
+  fee
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
 -- name: GetTransfer :one
 SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
+-- name: CreatePendingTransfer :one
+-- Moves no money until it is completed
+INSERT INTO transfers (
+  from_account_id,
+  to_account_id,
+  amount,
+  fee,
+  status
+) VALUES (
+  $1, $2, $3, $4, 'pending'
+) RETURNING *;
+
+-- name: UpdateTransferStatus :one
+-- The conversion of a cross-currency transfer is only known once it is completed
+UPDATE transfers
+SET
+  status = sqlc.arg(status),
+  to_amount = COALESCE(sqlc.narg(to_amount), to_amount),
+  exchange_rate = COALESCE(sqlc.narg(exchange_rate), exchange_rate)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetReversedAmount :one
+-- The part of the transfer already refunded, in the currency of its from account.
+-- That is the to amount of a cross-currency reversal.
+SELECT COALESCE(SUM(COALESCE(to_amount, amount)), 0)::bigint AS reversed_amount
+FROM transfers
+WHERE reversed_transfer_id = $1;
+
 -- name: ListTransfers :many
+-- Lists the transfers in and out of an account.
+-- Keyset pagination, the next page starts after the last id of the previous page.
+-- Filters are optional, a NULL filter matches every transfer.
 SELECT * FROM transfers
-WHERE 
-    from_account_id = $1 OR
-    to_account_id = $2
+WHERE
+  (CASE sqlc.narg(direction)::varchar
+    WHEN 'incoming' THEN to_account_id = sqlc.arg(account_id)
+    WHEN 'outgoing' THEN from_account_id = sqlc.arg(account_id)
+    ELSE from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id)
+  END) AND
+  id > sqlc.arg(after_id) AND
+  (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time)::timestamptz) AND
+  (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time)::timestamptz) AND
+  (sqlc.narg(min_amount)::bigint IS NULL OR amount >= sqlc.narg(min_amount)::bigint) AND
+  (sqlc.narg(max_amount)::bigint IS NULL OR amount <= sqlc.narg(max_amount)::bigint) AND
+  (sqlc.narg(status)::transfer_status IS NULL OR status = sqlc.narg(status)::transfer_status)
 ORDER BY id
-LIMIT $3
-OFFSET $4;
+LIMIT sqlc.arg('limit');
@@ -13,12 +13,22 @@ RETURNING *;
 SELECT * FROM users
 WHERE username = $1 LIMIT 1;
 
+-- name: GetUserForUpdate :one
+SELECT * FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: UpdateUser :one
 UPDATE users
 SET
   hashed_password = COALESCE(sqlc.narg(hashed_password), hashed_password),
   password_changed_at = COALESCE(sqlc.narg(password_changed_at), password_changed_at),
   full_name = COALESCE(sqlc.narg(full_name), full_name),
-  email = COALESCE(sqlc.narg(email), email)
+  email = COALESCE(sqlc.narg(email), email),
+  -- A new email address has to be verified again
+  is_email_verified = CASE
+    WHEN sqlc.narg(email) <> email THEN false
+    ELSE COALESCE(sqlc.narg(is_email_verified), is_email_verified)
+  END
 WHERE username = sqlc.arg(username)
 RETURNING *;
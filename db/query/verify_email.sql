@@ -0,0 +1,20 @@
+-- name: CreateVerifyEmail :one
+INSERT INTO verify_emails (
+  username,
+  email,
+  secret_code
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: UpdateVerifyEmail :one
+-- Only an unused and unexpired code can be used, otherwise no row is returned
+UPDATE verify_emails
+SET is_used = true
+WHERE
+  id = sqlc.arg(id)
+  AND secret_code = sqlc.arg(secret_code)
+  AND is_used = false
+  AND expires_at > now()
+RETURNING *;
@@ -93,9 +93,9 @@ func TestListAccounts(t *testing.T) {
 	}
 
 	arg := ListAccountsParams{
-		Owner:  lastAccount.Owner,
-		Limit:  5,
-		Offset: 0,
+		Owner:   lastAccount.Owner,
+		AfterID: 0,
+		Limit:   5,
 	}
 
 	accounts, err := testQueries.ListAccounts(context.Background(), arg)
@@ -109,3 +109,32 @@ func TestListAccounts(t *testing.T) {
 		require.Equal(t, lastAccount.Owner, account.Owner)
 	}
 }
+
+func TestListAccountsAfterID(t *testing.T) {
+	requireDB(t)
+	account1 := createRandomAccount(t)
+
+	// The unique owner/currency constraint allows one account per currency
+	account2, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    account1.Owner,
+		Balance:  0,
+		Currency: otherCurrency(account1.Currency),
+	})
+	require.NoError(t, err)
+
+	accounts, err := testQueries.ListAccounts(context.Background(), ListAccountsParams{
+		Owner:   account1.Owner,
+		AfterID: account1.ID,
+		Limit:   5,
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Equal(t, account2.ID, accounts[0].ID)
+}
+
+func otherCurrency(currency string) string {
+	if currency == util.USD {
+		return util.EUR
+	}
+	return util.USD
+}
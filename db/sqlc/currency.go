@@ -0,0 +1,26 @@
+package db
+
+import (
+	"context"
+	"simple-bank/util"
+)
+
+// Replaces the in-memory currency registry with the `currencies` table
+func LoadCurrencies(ctx context.Context, q Querier) error {
+	rows, err := q.ListCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	currencies := make([]util.Currency, len(rows))
+	for i, row := range rows {
+		currencies[i] = util.Currency{
+			Code:       row.Code,
+			MinorUnits: row.MinorUnits,
+			Enabled:    row.Enabled,
+		}
+	}
+
+	util.SetCurrencies(currencies)
+	return nil
+}
@@ -0,0 +1,118 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: currency.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createCurrency = `-- name: CreateCurrency :one
+INSERT INTO currencies (
+  code,
+  minor_units,
+  enabled
+) VALUES (
+  $1, $2, $3
+) RETURNING code, minor_units, enabled, created_at, updated_at
+`
+
+type CreateCurrencyParams struct {
+	Code       string `json:"code"`
+	MinorUnits int32  `json:"minor_units"`
+	Enabled    bool   `json:"enabled"`
+}
+
+func (q *Queries) CreateCurrency(ctx context.Context, arg CreateCurrencyParams) (Currency, error) {
+	row := q.db.QueryRowContext(ctx, createCurrency, arg.Code, arg.MinorUnits, arg.Enabled)
+	var i Currency
+	err := row.Scan(
+		&i.Code,
+		&i.MinorUnits,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCurrency = `-- name: GetCurrency :one
+SELECT code, minor_units, enabled, created_at, updated_at FROM currencies
+WHERE code = $1 LIMIT 1
+`
+
+func (q *Queries) GetCurrency(ctx context.Context, code string) (Currency, error) {
+	row := q.db.QueryRowContext(ctx, getCurrency, code)
+	var i Currency
+	err := row.Scan(
+		&i.Code,
+		&i.MinorUnits,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listCurrencies = `-- name: ListCurrencies :many
+SELECT code, minor_units, enabled, created_at, updated_at FROM currencies
+ORDER BY code
+`
+
+func (q *Queries) ListCurrencies(ctx context.Context) ([]Currency, error) {
+	rows, err := q.db.QueryContext(ctx, listCurrencies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Currency{}
+	for rows.Next() {
+		var i Currency
+		if err := rows.Scan(
+			&i.Code,
+			&i.MinorUnits,
+			&i.Enabled,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCurrency = `-- name: UpdateCurrency :one
+UPDATE currencies
+SET
+  enabled = $1,
+  updated_at = now()
+WHERE code = $2
+RETURNING code, minor_units, enabled, created_at, updated_at
+`
+
+type UpdateCurrencyParams struct {
+	Enabled bool   `json:"enabled"`
+	Code    string `json:"code"`
+}
+
+func (q *Queries) UpdateCurrency(ctx context.Context, arg UpdateCurrencyParams) (Currency, error) {
+	row := q.db.QueryRowContext(ctx, updateCurrency, arg.Enabled, arg.Code)
+	var i Currency
+	err := row.Scan(
+		&i.Code,
+		&i.MinorUnits,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"simple-bank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateCurrency(t *testing.T) {
+	requireDB(t)
+
+	// Seeded disabled by the `add_currencies` migration
+	currency, err := testQueries.UpdateCurrency(context.Background(), UpdateCurrencyParams{
+		Code:    "GBP",
+		Enabled: true,
+	})
+	require.NoError(t, err)
+	require.True(t, currency.Enabled)
+	require.Equal(t, int32(2), currency.MinorUnits)
+
+	currency, err = testQueries.UpdateCurrency(context.Background(), UpdateCurrencyParams{
+		Code:    "GBP",
+		Enabled: false,
+	})
+	require.NoError(t, err)
+	require.False(t, currency.Enabled)
+}
+
+func TestLoadCurrencies(t *testing.T) {
+	requireDB(t)
+	t.Cleanup(func() {
+		// Other tests expect only the built-in currencies
+		util.SetCurrencies([]util.Currency{
+			{Code: util.USD, MinorUnits: 2, Enabled: true},
+			{Code: util.EUR, MinorUnits: 2, Enabled: true},
+			{Code: util.INR, MinorUnits: 2, Enabled: true},
+			{Code: util.CAD, MinorUnits: 2, Enabled: true},
+		})
+	})
+
+	err := LoadCurrencies(context.Background(), testQueries)
+	require.NoError(t, err)
+
+	require.True(t, util.IsSupportedCurrency(util.USD))
+
+	jpy, ok := util.LookupCurrency("JPY")
+	require.True(t, ok)
+	require.Zero(t, jpy.MinorUnits)
+}
@@ -7,36 +7,56 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id,
+  from_amount,
+  to_amount,
+  exchange_rate
 ) VALUES (
-  $1, $2
-) RETURNING id, account_id, amount, created_at
+  $1, $2, $3, $4, $5, $6
+) RETURNING id, account_id, amount, created_at, transfer_id, from_amount, to_amount, exchange_rate
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID    int64          `json:"account_id"`
+	Amount       int64          `json:"amount"`
+	TransferID   sql.NullInt64  `json:"transfer_id"`
+	FromAmount   sql.NullInt64  `json:"from_amount"`
+	ToAmount     sql.NullInt64  `json:"to_amount"`
+	ExchangeRate sql.NullString `json:"exchange_rate"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRowContext(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.TransferID,
+		arg.FromAmount,
+		arg.ToAmount,
+		arg.ExchangeRate,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.FromAmount,
+		&i.ToAmount,
+		&i.ExchangeRate,
 	)
 	return i, err
 }
 
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id, from_amount, to_amount, exchange_rate FROM entries
 WHERE id = $1 LIMIT 1
 `
 
@@ -48,26 +68,55 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.FromAmount,
+		&i.ToAmount,
+		&i.ExchangeRate,
 	)
 	return i, err
 }
 
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
-WHERE account_id = $1
+SELECT id, account_id, amount, created_at, transfer_id, from_amount, to_amount, exchange_rate FROM entries
+WHERE
+  account_id = $1 AND
+  id > $2 AND
+  ($3::timestamptz IS NULL OR created_at >= $3::timestamptz) AND
+  ($4::timestamptz IS NULL OR created_at < $4::timestamptz) AND
+  ($5::varchar IS NULL OR
+    ($5::varchar = 'incoming' AND amount > 0) OR
+    ($5::varchar = 'outgoing' AND amount < 0)) AND
+  ($6::bigint IS NULL OR abs(amount) >= $6::bigint) AND
+  ($7::bigint IS NULL OR abs(amount) <= $7::bigint)
 ORDER BY id
-LIMIT $2
-OFFSET $3
+LIMIT $8
 `
 
 type ListEntriesParams struct {
-	AccountID int64 `json:"account_id"`
-	Limit     int32 `json:"limit"`
-	Offset    int32 `json:"offset"`
+	AccountID int64          `json:"account_id"`
+	AfterID   int64          `json:"after_id"`
+	StartTime sql.NullTime   `json:"start_time"`
+	EndTime   sql.NullTime   `json:"end_time"`
+	Direction sql.NullString `json:"direction"`
+	MinAmount sql.NullInt64  `json:"min_amount"`
+	MaxAmount sql.NullInt64  `json:"max_amount"`
+	Limit     int32          `json:"limit"`
 }
 
+// Keyset pagination, the next page starts after the last id of the previous page.
+// Filters are optional, a NULL filter matches every entry.
+// The amount range is compared with the absolute amount, so it works for both directions.
 func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
-	rows, err := q.db.QueryContext(ctx, listEntries, arg.AccountID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listEntries,
+		arg.AccountID,
+		arg.AfterID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Direction,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +129,10 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.TransferID,
+			&i.FromAmount,
+			&i.ToAmount,
+			&i.ExchangeRate,
 		); err != nil {
 			return nil, err
 		}
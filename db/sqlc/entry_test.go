@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEntriesFilters(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := fundAccount(t, createRandomAccount(t), 1000)
+	account2 := fundAccount(t, createRandomAccount(t), 1000)
+
+	for _, amount := range []int64{10, 20, 30} {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        40,
+	})
+	require.NoError(t, err)
+
+	entries, err := testQueries.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: account1.ID,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	entries, err = testQueries.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: account1.ID,
+		Direction: sql.NullString{String: "outgoing", Valid: true},
+		MinAmount: sql.NullInt64{Int64: 20, Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		require.Equal(t, account1.ID, entry.AccountID)
+		require.Less(t, entry.Amount, int64(-10))
+	}
+
+	transfers, err := testQueries.ListTransfers(context.Background(), ListTransfersParams{
+		AccountID: account1.ID,
+		Direction: sql.NullString{String: "incoming", Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	require.Equal(t, account1.ID, transfers[0].ToAccountID)
+
+	transfers, err = testQueries.ListTransfers(context.Background(), ListTransfersParams{
+		AccountID: account1.ID,
+		MaxAmount: sql.NullInt64{Int64: 20, Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, transfers, 2)
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"simple-bank/fees"
+)
+
+// Owns the fee revenue account of each currency
+const FeesUsername = "fees"
+
+// Reports whether the account belongs to one of the bank's own users, which only bookkeeping may pay into
+func IsHouseAccount(account Account) bool {
+	return account.Owner == SystemUsername || account.Owner == FeesUsername
+}
+
+// Returns the fee a transfer of `amount` out of the account is charged, 0 if no fee rule matches it
+func (store *SQLStore) GetTransferFee(ctx context.Context, account Account, amount int64) (int64, error) {
+	return transferFee(ctx, store.Queries, account, amount)
+}
+
+func transferFee(ctx context.Context, q *Queries, account Account, amount int64) (int64, error) {
+	rule, err := q.GetFeeRule(ctx, GetFeeRuleParams{
+		Currency:    account.Currency,
+		AccountType: account.Type,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return fees.Rule{
+		Flat:    rule.FlatFee,
+		RateBps: rule.RateBps,
+		Min:     rule.MinFee,
+		Max:     rule.MaxFee,
+	}.Fee(amount)
+}
+
+// Records the fee of the transfer, a debit on the from account and a credit on the fee account of its currency.
+// The from account must already be locked, the fee account is locked after every other account of the transfer.
+func bookFee(ctx context.Context, q *Queries, transfer Transfer, currency string) (feeEntry Entry, fromAccount Account, err error) {
+	feeAccount, err := q.GetOrCreateFeeAccount(ctx, currency)
+	if err != nil {
+		return
+	}
+
+	feeEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  transfer.FromAccountID,
+		Amount:     -transfer.Fee,
+		TransferID: sql.NullInt64{Int64: transfer.ID, Valid: true},
+	})
+	if err != nil {
+		return
+	}
+
+	_, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  feeAccount.ID,
+		Amount:     transfer.Fee,
+		TransferID: sql.NullInt64{Int64: transfer.ID, Valid: true},
+	})
+	if err != nil {
+		return
+	}
+
+	fromAccount, _, err = addMoney(ctx, q, transfer.FromAccountID, -transfer.Fee, feeAccount.ID, transfer.Fee)
+	return
+}
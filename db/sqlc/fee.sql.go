@@ -0,0 +1,99 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: fee.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createFeeRule = `-- name: CreateFeeRule :one
+INSERT INTO fee_rules (
+  currency,
+  account_type,
+  flat_fee,
+  rate_bps,
+  min_fee,
+  max_fee
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING id, currency, account_type, flat_fee, rate_bps, min_fee, max_fee, created_at, updated_at
+`
+
+type CreateFeeRuleParams struct {
+	Currency    sql.NullString  `json:"currency"`
+	AccountType NullAccountType `json:"account_type"`
+	FlatFee     int64           `json:"flat_fee"`
+	RateBps     int64           `json:"rate_bps"`
+	MinFee      int64           `json:"min_fee"`
+	MaxFee      int64           `json:"max_fee"`
+}
+
+func (q *Queries) CreateFeeRule(ctx context.Context, arg CreateFeeRuleParams) (FeeRule, error) {
+	row := q.db.QueryRowContext(ctx, createFeeRule,
+		arg.Currency,
+		arg.AccountType,
+		arg.FlatFee,
+		arg.RateBps,
+		arg.MinFee,
+		arg.MaxFee,
+	)
+	var i FeeRule
+	err := row.Scan(
+		&i.ID,
+		&i.Currency,
+		&i.AccountType,
+		&i.FlatFee,
+		&i.RateBps,
+		&i.MinFee,
+		&i.MaxFee,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteFeeRule = `-- name: DeleteFeeRule :exec
+DELETE FROM fee_rules
+WHERE id = $1
+`
+
+func (q *Queries) DeleteFeeRule(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteFeeRule, id)
+	return err
+}
+
+const getFeeRule = `-- name: GetFeeRule :one
+SELECT id, currency, account_type, flat_fee, rate_bps, min_fee, max_fee, created_at, updated_at FROM fee_rules
+WHERE
+  (currency IS NULL OR currency = $1::varchar) AND
+  (account_type IS NULL OR account_type = $2::account_type)
+ORDER BY currency IS NULL, account_type IS NULL
+LIMIT 1
+`
+
+type GetFeeRuleParams struct {
+	Currency    string      `json:"currency"`
+	AccountType AccountType `json:"account_type"`
+}
+
+// The most specific rule for the currency and account type, a currency rule wins over an account type rule
+func (q *Queries) GetFeeRule(ctx context.Context, arg GetFeeRuleParams) (FeeRule, error) {
+	row := q.db.QueryRowContext(ctx, getFeeRule, arg.Currency, arg.AccountType)
+	var i FeeRule
+	err := row.Scan(
+		&i.ID,
+		&i.Currency,
+		&i.AccountType,
+		&i.FlatFee,
+		&i.RateBps,
+		&i.MinFee,
+		&i.MaxFee,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"simple-bank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Fee rules in the tests only match business accounts, the accounts of the other tests are personal
+func createBusinessAccount(t *testing.T, currency string, balance int64) Account {
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Balance:  0,
+		Currency: currency,
+		Type:     NullAccountType{AccountType: AccountTypeBusiness, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountTypeBusiness, account.Type)
+
+	return fundAccount(t, account, balance)
+}
+
+func newFeeRule(t *testing.T, arg CreateFeeRuleParams) FeeRule {
+	arg.AccountType = NullAccountType{AccountType: AccountTypeBusiness, Valid: true}
+
+	rule, err := testQueries.CreateFeeRule(context.Background(), arg)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := testQueries.DeleteFeeRule(context.Background(), rule.ID)
+		require.NoError(t, err)
+	})
+
+	return rule
+}
+
+func TestGetTransferFee(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account := createBusinessAccount(t, util.USD, 0)
+
+	fee, err := store.GetTransferFee(context.Background(), account, 1000)
+	require.NoError(t, err)
+	require.Zero(t, fee)
+
+	newFeeRule(t, CreateFeeRuleParams{FlatFee: 5})
+
+	fee, err = store.GetTransferFee(context.Background(), account, 1000)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), fee)
+
+	// The currency rule wins over the rule for every currency
+	newFeeRule(t, CreateFeeRuleParams{
+		Currency: sql.NullString{String: util.USD, Valid: true},
+		RateBps:  100,
+		MinFee:   3,
+	})
+
+	fee, err = store.GetTransferFee(context.Background(), account, 1000)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), fee)
+
+	fee, err = store.GetTransferFee(context.Background(), account, 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), fee)
+}
+
+func TestTransferTxFee(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	newFeeRule(t, CreateFeeRuleParams{
+		Currency: sql.NullString{String: util.EUR, Valid: true},
+		FlatFee:  1,
+		RateBps:  100,
+	})
+
+	account1 := createBusinessAccount(t, util.EUR, 200)
+	account2 := createBusinessAccount(t, util.EUR, 0)
+
+	feeAccount, err := testQueries.GetOrCreateFeeAccount(context.Background(), util.EUR)
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(2), result.Transfer.Fee)
+	require.Equal(t, int64(-100), result.FromEntry.Amount)
+	require.Equal(t, int64(-2), result.FeeEntry.Amount)
+	require.Equal(t, account1.ID, result.FeeEntry.AccountID)
+	require.Equal(t, int64(98), result.FromAccount.Balance)
+	require.Equal(t, int64(100), result.ToAccount.Balance)
+
+	updatedFeeAccount, err := testQueries.GetAccount(context.Background(), feeAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, feeAccount.Balance+2, updatedFeeAccount.Balance)
+
+	rows, err := testQueries.ListUnbalancedTransfers(context.Background())
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NotEqual(t, result.Transfer.ID, row.ID)
+	}
+
+	// The fee counts towards the balance, so the rest of the balance can't be moved out
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        98,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCompleteTransferTxFee(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := createBusinessAccount(t, util.EUR, 100)
+	account2 := createBusinessAccount(t, util.EUR, 0)
+
+	// The fee of a pending transfer is the one it was created with
+	transfer, err := testQueries.CreatePendingTransfer(context.Background(), CreatePendingTransferParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        50,
+		Fee:           7,
+	})
+	require.NoError(t, err)
+
+	result, err := store.CompleteTransferTx(context.Background(), CompleteTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, TransferStatusCompleted, result.Transfer.Status)
+	require.Equal(t, int64(-7), result.FeeEntry.Amount)
+	require.Equal(t, int64(43), result.FromAccount.Balance)
+}
@@ -0,0 +1,145 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: idempotency_key.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const createIdempotencyKey = `-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+  username,
+  key,
+  request_hash,
+  claim_id
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (username, key) DO NOTHING
+RETURNING username, key, request_hash, response_code, response_body, created_at, claim_id
+`
+
+type CreateIdempotencyKeyParams struct {
+	Username    string        `json:"username"`
+	Key         string        `json:"key"`
+	RequestHash string        `json:"request_hash"`
+	ClaimID     uuid.NullUUID `json:"claim_id"`
+}
+
+// Claims the key, no row comes back if it was already claimed.
+// A claim is never taken over: a request that didn't finish may still have committed,
+// so its retries are rejected until the key is released or its response is saved.
+func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, createIdempotencyKey,
+		arg.Username,
+		arg.Key,
+		arg.RequestHash,
+		arg.ClaimID,
+	)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Username,
+		&i.Key,
+		&i.RequestHash,
+		&i.ResponseCode,
+		&i.ResponseBody,
+		&i.CreatedAt,
+		&i.ClaimID,
+	)
+	return i, err
+}
+
+const deleteIdempotencyKey = `-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE
+  username = $1 AND
+  key = $2 AND
+  claim_id = $3 AND
+  response_code IS NULL
+`
+
+type DeleteIdempotencyKeyParams struct {
+	Username string        `json:"username"`
+	Key      string        `json:"key"`
+	ClaimID  uuid.NullUUID `json:"claim_id"`
+}
+
+// Releases the key of a failed request, only while the claim is still held by it.
+func (q *Queries) DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error {
+	_, err := q.db.ExecContext(ctx, deleteIdempotencyKey, arg.Username, arg.Key, arg.ClaimID)
+	return err
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT username, key, request_hash, response_code, response_body, created_at, claim_id FROM idempotency_keys
+WHERE username = $1 AND key = $2 LIMIT 1
+`
+
+type GetIdempotencyKeyParams struct {
+	Username string `json:"username"`
+	Key      string `json:"key"`
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, getIdempotencyKey, arg.Username, arg.Key)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Username,
+		&i.Key,
+		&i.RequestHash,
+		&i.ResponseCode,
+		&i.ResponseBody,
+		&i.CreatedAt,
+		&i.ClaimID,
+	)
+	return i, err
+}
+
+const updateIdempotencyKeyResponse = `-- name: UpdateIdempotencyKeyResponse :one
+UPDATE idempotency_keys
+SET
+  response_code = $1,
+  response_body = $2
+WHERE
+  username = $3 AND
+  key = $4 AND
+  claim_id = $5 AND
+  response_code IS NULL
+RETURNING username, key, request_hash, response_code, response_body, created_at, claim_id
+`
+
+type UpdateIdempotencyKeyResponseParams struct {
+	ResponseCode sql.NullInt32 `json:"response_code"`
+	ResponseBody []byte        `json:"response_body"`
+	Username     string        `json:"username"`
+	Key          string        `json:"key"`
+	ClaimID      uuid.NullUUID `json:"claim_id"`
+}
+
+// Saves the response, no row comes back unless the claim is still held by the request.
+func (q *Queries) UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) (IdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, updateIdempotencyKeyResponse,
+		arg.ResponseCode,
+		arg.ResponseBody,
+		arg.Username,
+		arg.Key,
+		arg.ClaimID,
+	)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.Username,
+		&i.Key,
+		&i.RequestHash,
+		&i.ResponseCode,
+		&i.ResponseBody,
+		&i.CreatedAt,
+		&i.ClaimID,
+	)
+	return i, err
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"simple-bank/util"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyKeyClaim(t *testing.T) {
+	requireDB(t)
+
+	user := createRandomUser(t)
+	arg := CreateIdempotencyKeyParams{
+		Username:    user.Username,
+		Key:         util.RandomString(16),
+		RequestHash: util.RandomString(64),
+		ClaimID:     uuid.NullUUID{UUID: uuid.New(), Valid: true},
+	}
+
+	key, err := testQueries.CreateIdempotencyKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ClaimID, key.ClaimID)
+	require.False(t, key.ResponseCode.Valid)
+
+	// A claimed key is never taken over, even by a retry of the same request
+	retry := arg
+	retry.ClaimID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	_, err = testQueries.CreateIdempotencyKey(context.Background(), retry)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Only the request holding the claim can release the key or save its response
+	err = testQueries.DeleteIdempotencyKey(context.Background(), DeleteIdempotencyKeyParams{
+		Username: arg.Username,
+		Key:      arg.Key,
+		ClaimID:  retry.ClaimID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateIdempotencyKeyResponse(context.Background(), UpdateIdempotencyKeyResponseParams{
+		Username:     arg.Username,
+		Key:          arg.Key,
+		ClaimID:      retry.ClaimID,
+		ResponseCode: sql.NullInt32{Int32: 200, Valid: true},
+		ResponseBody: []byte(`{}`),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	key, err = testQueries.UpdateIdempotencyKeyResponse(context.Background(), UpdateIdempotencyKeyResponseParams{
+		Username:     arg.Username,
+		Key:          arg.Key,
+		ClaimID:      arg.ClaimID,
+		ResponseCode: sql.NullInt32{Int32: 200, Valid: true},
+		ResponseBody: []byte(`{}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(200), key.ResponseCode.Int32)
+
+	// A key with a saved response is kept for replays
+	err = testQueries.DeleteIdempotencyKey(context.Background(), DeleteIdempotencyKeyParams{
+		Username: arg.Username,
+		Key:      arg.Key,
+		ClaimID:  arg.ClaimID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username: arg.Username,
+		Key:      arg.Key,
+	})
+	require.NoError(t, err)
+}
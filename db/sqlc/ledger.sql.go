@@ -0,0 +1,167 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: ledger.sql
+
+package db
+
+import (
+	"context"
+)
+
+const listBalanceMismatches = `-- name: ListBalanceMismatches :many
+SELECT
+  a.id,
+  a.owner,
+  a.currency,
+  a.balance,
+  COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(e.amount), 0)
+ORDER BY a.id
+`
+
+type ListBalanceMismatchesRow struct {
+	ID           int64  `json:"id"`
+	Owner        string `json:"owner"`
+	Currency     string `json:"currency"`
+	Balance      int64  `json:"balance"`
+	EntriesTotal int64  `json:"entries_total"`
+}
+
+// Accounts whose balance is not the sum of their entries
+func (q *Queries) ListBalanceMismatches(ctx context.Context) ([]ListBalanceMismatchesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBalanceMismatches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBalanceMismatchesRow{}
+	for rows.Next() {
+		var i ListBalanceMismatchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Currency,
+			&i.Balance,
+			&i.EntriesTotal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCurrencyImbalances = `-- name: ListCurrencyImbalances :many
+SELECT
+  a.currency,
+  SUM(e.amount)::bigint AS entries_total
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+GROUP BY a.currency
+HAVING SUM(e.amount) <> 0
+ORDER BY a.currency
+`
+
+type ListCurrencyImbalancesRow struct {
+	Currency     string `json:"currency"`
+	EntriesTotal int64  `json:"entries_total"`
+}
+
+// Currencies whose entries don't net to zero
+func (q *Queries) ListCurrencyImbalances(ctx context.Context) ([]ListCurrencyImbalancesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCurrencyImbalances)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCurrencyImbalancesRow{}
+	for rows.Next() {
+		var i ListCurrencyImbalancesRow
+		if err := rows.Scan(&i.Currency, &i.EntriesTotal); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnbalancedTransfers = `-- name: ListUnbalancedTransfers :many
+SELECT
+  t.id,
+  t.from_account_id,
+  t.to_account_id,
+  t.amount,
+  COUNT(e.id) AS entry_count,
+  COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM transfers t
+LEFT JOIN entries e ON e.transfer_id = t.id
+GROUP BY t.id
+HAVING
+  (t.status NOT IN ('completed', 'reversed') AND COUNT(e.id) <> 0) OR
+  (t.status IN ('completed', 'reversed') AND (
+    COUNT(e.id) <> (CASE WHEN t.exchange_rate IS NULL THEN 2 ELSE 4 END) + (CASE WHEN t.fee > 0 THEN 2 ELSE 0 END) OR
+    COUNT(e.id) FILTER (WHERE e.account_id = t.from_account_id) <> (CASE WHEN t.fee > 0 THEN 2 ELSE 1 END) OR
+    COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.from_account_id), 0) <> -(t.amount + t.fee) OR
+    COUNT(e.id) FILTER (WHERE e.account_id = t.to_account_id AND e.amount = COALESCE(t.to_amount, t.amount)) <> 1
+  ))
+ORDER BY t.id
+`
+
+type ListUnbalancedTransfersRow struct {
+	ID            int64 `json:"id"`
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	Amount        int64 `json:"amount"`
+	EntryCount    int64 `json:"entry_count"`
+	EntriesTotal  int64 `json:"entries_total"`
+}
+
+// Transfers that don't have exactly one debit entry on the from account and one credit entry on the to account.
+// A cross-currency transfer also has one entry on the system account of each currency,
+// and a transfer with a fee has one more debit on the from account and a credit on the fee account.
+// Transfers that were never completed must have no entries.
+func (q *Queries) ListUnbalancedTransfers(ctx context.Context) ([]ListUnbalancedTransfersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUnbalancedTransfers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUnbalancedTransfersRow{}
+	for rows.Next() {
+		var i ListUnbalancedTransfersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.EntryCount,
+			&i.EntriesTotal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListBalanceMismatches(t *testing.T) {
+	requireDB(t)
+
+	// Funded without entries, so the balance can't be explained by the ledger
+	account := fundAccount(t, createRandomAccount(t), 10)
+
+	rows, err := testQueries.ListBalanceMismatches(context.Background())
+	require.NoError(t, err)
+
+	var found bool
+	for _, row := range rows {
+		if row.ID == account.ID {
+			found = true
+			require.Equal(t, int64(10), row.Balance)
+			require.Zero(t, row.EntriesTotal)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestListUnbalancedTransfers(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := fundAccount(t, createRandomAccount(t), 10)
+	account2 := createRandomAccount(t)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	rows, err := testQueries.ListUnbalancedTransfers(context.Background())
+	require.NoError(t, err)
+
+	for _, row := range rows {
+		require.NotEqual(t, result.Transfer.ID, row.ID)
+	}
+}
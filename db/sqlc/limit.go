@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"simple-bank/util"
+	"time"
+)
+
+// Returned when a transfer goes over an outgoing limit of its from account
+var ErrLimitExceeded = errors.New("transfer limit exceeded")
+
+// Outgoing transfer limits in the minor units of the currency, 0 means no limit
+type TransferLimits struct {
+	PerTransaction int64 `json:"per_transaction"`
+	Daily          int64 `json:"daily"`
+	Monthly        int64 `json:"monthly"`
+}
+
+// Configures the `Store` returned by `NewStore`
+type StoreOption func(store *SQLStore)
+
+// Limits for the accounts and currencies that don't have their own in the `limits` table
+func WithDefaultLimits(limits TransferLimits) StoreOption {
+	return func(store *SQLStore) {
+		store.defaultLimits = limits
+	}
+}
+
+// Returns the limits that apply to the account, each one from the most specific place that sets it
+func (store *SQLStore) GetTransferLimits(ctx context.Context, account Account) (TransferLimits, error) {
+	return store.transferLimits(ctx, store.Queries, account)
+}
+
+func (store *SQLStore) transferLimits(ctx context.Context, q *Queries, account Account) (TransferLimits, error) {
+	limits := store.defaultLimits
+
+	rows, err := q.ListAccountLimits(ctx, ListAccountLimitsParams{
+		Currency:  account.Currency,
+		AccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+	})
+	if err != nil {
+		return limits, err
+	}
+
+	for _, row := range rows {
+		if row.PerTransaction.Valid {
+			limits.PerTransaction = row.PerTransaction.Int64
+		}
+		if row.Daily.Valid {
+			limits.Daily = row.Daily.Int64
+		}
+		if row.Monthly.Valid {
+			limits.Monthly = row.Monthly.Int64
+		}
+	}
+
+	return limits, nil
+}
+
+// Returns `ErrLimitExceeded` if the transfer of `amount` out of the account goes over one of its limits.
+// Called once the transfer is booked under the account lock, so the totals include it
+// and concurrent transfers from the account can't both slip under a limit.
+// Days and months are in UTC.
+func (store *SQLStore) checkTransferLimits(ctx context.Context, q *Queries, account Account, amount int64) error {
+	limits, err := store.transferLimits(ctx, q, account)
+	if err != nil {
+		return err
+	}
+
+	if limits.PerTransaction > 0 && amount > limits.PerTransaction {
+		return fmt.Errorf("%w: account [%d] can transfer at most %s at a time",
+			ErrLimitExceeded, account.ID, util.Money{Amount: limits.PerTransaction, Currency: account.Currency})
+	}
+
+	if limits.Daily == 0 && limits.Monthly == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	totals, err := q.GetOutgoingTotals(ctx, GetOutgoingTotalsParams{
+		AccountID:  account.ID,
+		DayStart:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		MonthStart: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		return err
+	}
+
+	if limits.Daily > 0 && totals.DailyTotal > limits.Daily {
+		remaining := max(limits.Daily-totals.DailyTotal+amount, 0)
+		return fmt.Errorf("%w: account [%d] can transfer %s more today",
+			ErrLimitExceeded, account.ID, util.Money{Amount: remaining, Currency: account.Currency})
+	}
+	if limits.Monthly > 0 && totals.MonthlyTotal > limits.Monthly {
+		remaining := max(limits.Monthly-totals.MonthlyTotal+amount, 0)
+		return fmt.Errorf("%w: account [%d] can transfer %s more this month",
+			ErrLimitExceeded, account.ID, util.Money{Amount: remaining, Currency: account.Currency})
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: limit.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const getOutgoingTotals = `-- name: GetOutgoingTotals :one
+SELECT
+  COALESCE(SUM(amount) FILTER (WHERE created_at >= $1), 0)::bigint AS daily_total,
+  COALESCE(SUM(amount), 0)::bigint AS monthly_total
+FROM transfers
+WHERE
+  from_account_id = $2 AND
+  status IN ('completed', 'pending') AND
+  reversed_transfer_id IS NULL AND
+  created_at >= $3
+`
+
+type GetOutgoingTotalsParams struct {
+	DayStart   time.Time `json:"day_start"`
+	AccountID  int64     `json:"account_id"`
+	MonthStart time.Time `json:"month_start"`
+}
+
+type GetOutgoingTotalsRow struct {
+	DailyTotal   int64 `json:"daily_total"`
+	MonthlyTotal int64 `json:"monthly_total"`
+}
+
+// What the account transferred out since the start of the day and of the month.
+// Pending transfers count as they will move the money, fees and reversals paid out of the account don't.
+func (q *Queries) GetOutgoingTotals(ctx context.Context, arg GetOutgoingTotalsParams) (GetOutgoingTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getOutgoingTotals, arg.DayStart, arg.AccountID, arg.MonthStart)
+	var i GetOutgoingTotalsRow
+	err := row.Scan(&i.DailyTotal, &i.MonthlyTotal)
+	return i, err
+}
+
+const listAccountLimits = `-- name: ListAccountLimits :many
+SELECT id, currency, account_id, per_transaction, daily, monthly, created_at, updated_at FROM limits
+WHERE currency = $1 AND (account_id IS NULL OR account_id = $2)
+ORDER BY account_id NULLS FIRST
+`
+
+type ListAccountLimitsParams struct {
+	Currency  string        `json:"currency"`
+	AccountID sql.NullInt64 `json:"account_id"`
+}
+
+// The limits of the currency and of the account, the account row comes last so it overrides the currency row
+func (q *Queries) ListAccountLimits(ctx context.Context, arg ListAccountLimitsParams) ([]Limit, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountLimits, arg.Currency, arg.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Limit{}
+	for rows.Next() {
+		var i Limit
+		if err := rows.Scan(
+			&i.ID,
+			&i.Currency,
+			&i.AccountID,
+			&i.PerTransaction,
+			&i.Daily,
+			&i.Monthly,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertLimit = `-- name: UpsertLimit :one
+INSERT INTO limits (
+  currency,
+  account_id,
+  per_transaction,
+  daily,
+  monthly
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (currency, (COALESCE(account_id, 0))) DO UPDATE SET
+  per_transaction = EXCLUDED.per_transaction,
+  daily = EXCLUDED.daily,
+  monthly = EXCLUDED.monthly,
+  updated_at = now()
+RETURNING id, currency, account_id, per_transaction, daily, monthly, created_at, updated_at
+`
+
+type UpsertLimitParams struct {
+	Currency       string        `json:"currency"`
+	AccountID      sql.NullInt64 `json:"account_id"`
+	PerTransaction sql.NullInt64 `json:"per_transaction"`
+	Daily          sql.NullInt64 `json:"daily"`
+	Monthly        sql.NullInt64 `json:"monthly"`
+}
+
+func (q *Queries) UpsertLimit(ctx context.Context, arg UpsertLimitParams) (Limit, error) {
+	row := q.db.QueryRowContext(ctx, upsertLimit,
+		arg.Currency,
+		arg.AccountID,
+		arg.PerTransaction,
+		arg.Daily,
+		arg.Monthly,
+	)
+	var i Limit
+	err := row.Scan(
+		&i.ID,
+		&i.Currency,
+		&i.AccountID,
+		&i.PerTransaction,
+		&i.Daily,
+		&i.Monthly,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Returns a new account funded with `balance` and another account of the same currency
+func createLimitedAccounts(t *testing.T, balance int64) (Account, Account) {
+	account := fundAccount(t, createRandomAccount(t), balance)
+	toAccount, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+
+	return account, toAccount
+}
+
+// Only account rows are set here, a currency row would apply to the accounts of every other test
+func setAccountLimit(t *testing.T, account Account, perTransaction sql.NullInt64, daily sql.NullInt64) {
+	_, err := testQueries.UpsertLimit(context.Background(), UpsertLimitParams{
+		Currency:       account.Currency,
+		AccountID:      sql.NullInt64{Int64: account.ID, Valid: true},
+		PerTransaction: perTransaction,
+		Daily:          daily,
+	})
+	require.NoError(t, err)
+}
+
+func TestGetTransferLimits(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB, WithDefaultLimits(TransferLimits{PerTransaction: 100, Daily: 200, Monthly: 300}))
+
+	account, _ := createLimitedAccounts(t, 0)
+
+	limits, err := store.GetTransferLimits(context.Background(), account)
+	require.NoError(t, err)
+	require.Equal(t, TransferLimits{PerTransaction: 100, Daily: 200, Monthly: 300}, limits)
+
+	// The account row only overrides what it sets
+	setAccountLimit(t, account, sql.NullInt64{}, sql.NullInt64{Int64: 50, Valid: true})
+
+	limits, err = store.GetTransferLimits(context.Background(), account)
+	require.NoError(t, err)
+	require.Equal(t, TransferLimits{PerTransaction: 100, Daily: 50, Monthly: 300}, limits)
+
+	// Upserting replaces the row, and 0 turns the limit off
+	setAccountLimit(t, account, sql.NullInt64{Int64: 0, Valid: true}, sql.NullInt64{})
+
+	limits, err = store.GetTransferLimits(context.Background(), account)
+	require.NoError(t, err)
+	require.Equal(t, TransferLimits{PerTransaction: 0, Daily: 200, Monthly: 300}, limits)
+}
+
+func TestTransferTxPerTransactionLimit(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account, toAccount := createLimitedAccounts(t, 100)
+	setAccountLimit(t, account, sql.NullInt64{Int64: 10, Valid: true}, sql.NullInt64{})
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        11,
+	})
+	require.ErrorIs(t, err, ErrLimitExceeded)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+}
+
+func TestTransferTxDailyLimit(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account, toAccount := createLimitedAccounts(t, 100)
+	setAccountLimit(t, account, sql.NullInt64{}, sql.NullInt64{Int64: 25, Valid: true})
+
+	for range 2 {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account.ID,
+			ToAccountID:   toAccount.ID,
+			Amount:        10,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	require.Contains(t, err.Error(), "can transfer 5 more today")
+
+	// The rejected transfer is rolled back
+	account, err = testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(80), account.Balance)
+
+	// Deposits and withdrawals aren't transfers and don't count
+	_, err = store.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    10,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        5,
+	})
+	require.NoError(t, err)
+}
+
+func TestTransferLimitTotals(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account, toAccount := createLimitedAccounts(t, 100)
+	setAccountLimit(t, account, sql.NullInt64{}, sql.NullInt64{Int64: 25, Valid: true})
+	setAccountLimit(t, toAccount, sql.NullInt64{}, sql.NullInt64{Int64: 5, Valid: true})
+
+	// A pending transfer counts, it will move the money
+	_, err := testQueries.CreatePendingTransfer(context.Background(), CreatePendingTransferParams{
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// Refunding the transfer isn't an outgoing transfer of the account that received it
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Transfer.ID,
+	})
+	require.NoError(t, err)
+
+	// The reversed transfer no longer counts either
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        15,
+	})
+	require.NoError(t, err)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrLimitExceeded)
+}
@@ -6,43 +6,249 @@ package db
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type AccountType string
+
+const (
+	AccountTypePersonal AccountType = "personal"
+	AccountTypeBusiness AccountType = "business"
+)
+
+func (e *AccountType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AccountType(s)
+	case string:
+		*e = AccountType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AccountType: %T", src)
+	}
+	return nil
+}
+
+type NullAccountType struct {
+	AccountType AccountType `json:"account_type"`
+	Valid       bool        `json:"valid"` // Valid is true if AccountType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAccountType) Scan(value interface{}) error {
+	if value == nil {
+		ns.AccountType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AccountType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAccountType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AccountType), nil
+}
+
+type TransferStatus string
+
+const (
+	TransferStatusPending   TransferStatus = "pending"
+	TransferStatusCompleted TransferStatus = "completed"
+	TransferStatusFailed    TransferStatus = "failed"
+	TransferStatusReversed  TransferStatus = "reversed"
+	TransferStatusCancelled TransferStatus = "cancelled"
+)
+
+func (e *TransferStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TransferStatus(s)
+	case string:
+		*e = TransferStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TransferStatus: %T", src)
+	}
+	return nil
+}
+
+type NullTransferStatus struct {
+	TransferStatus TransferStatus `json:"transfer_status"`
+	Valid          bool           `json:"valid"` // Valid is true if TransferStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullTransferStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.TransferStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.TransferStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullTransferStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.TransferStatus), nil
+}
+
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
-	Balance   int64     `json:"balance"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"created_at"`
+	ID             int64       `json:"id"`
+	Owner          string      `json:"owner"`
+	Balance        int64       `json:"balance"`
+	Currency       string      `json:"currency"`
+	CreatedAt      time.Time   `json:"created_at"`
+	OverdraftLimit int64       `json:"overdraft_limit"`
+	HeldAmount     int64       `json:"held_amount"`
+	Type           AccountType `json:"type"`
+}
+
+type Currency struct {
+	Code       string    `json:"code"`
+	MinorUnits int32     `json:"minor_units"`
+	Enabled    bool      `json:"enabled"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 type Entry struct {
-	ID        int64     `json:"id"`
-	AccountID int64     `json:"account_id"`
-	Amount    int64     `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
+	ID           int64          `json:"id"`
+	AccountID    int64          `json:"account_id"`
+	Amount       int64          `json:"amount"`
+	CreatedAt    time.Time      `json:"created_at"`
+	TransferID   sql.NullInt64  `json:"transfer_id"`
+	FromAmount   sql.NullInt64  `json:"from_amount"`
+	ToAmount     sql.NullInt64  `json:"to_amount"`
+	ExchangeRate sql.NullString `json:"exchange_rate"`
+}
+
+type FeeRule struct {
+	ID          int64           `json:"id"`
+	Currency    sql.NullString  `json:"currency"`
+	AccountType NullAccountType `json:"account_type"`
+	FlatFee     int64           `json:"flat_fee"`
+	RateBps     int64           `json:"rate_bps"`
+	MinFee      int64           `json:"min_fee"`
+	MaxFee      int64           `json:"max_fee"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+type Hold struct {
+	ID          int64         `json:"id"`
+	AccountID   int64         `json:"account_id"`
+	ToAccountID int64         `json:"to_account_id"`
+	Amount      int64         `json:"amount"`
+	Status      string        `json:"status"`
+	TransferID  sql.NullInt64 `json:"transfer_id"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+	Fee         int64         `json:"fee"`
+}
+
+type IdempotencyKey struct {
+	Username     string        `json:"username"`
+	Key          string        `json:"key"`
+	RequestHash  string        `json:"request_hash"`
+	ResponseCode sql.NullInt32 `json:"response_code"`
+	ResponseBody []byte        `json:"response_body"`
+	CreatedAt    time.Time     `json:"created_at"`
+	ClaimID      uuid.NullUUID `json:"claim_id"`
+}
+
+type Limit struct {
+	ID             int64         `json:"id"`
+	Currency       string        `json:"currency"`
+	AccountID      sql.NullInt64 `json:"account_id"`
+	PerTransaction sql.NullInt64 `json:"per_transaction"`
+	Daily          sql.NullInt64 `json:"daily"`
+	Monthly        sql.NullInt64 `json:"monthly"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+type Rate struct {
+	ID            int64     `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          string    `json:"rate"`
+	EffectiveAt   time.Time `json:"effective_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ScheduledTransfer struct {
+	ID              int64          `json:"id"`
+	Owner           string         `json:"owner"`
+	FromAccountID   int64          `json:"from_account_id"`
+	ToAccountID     int64          `json:"to_account_id"`
+	Amount          int64          `json:"amount"`
+	Currency        string         `json:"currency"`
+	CronExpression  sql.NullString `json:"cron_expression"`
+	IntervalSeconds sql.NullInt64  `json:"interval_seconds"`
+	Status          string         `json:"status"`
+	NextRunAt       time.Time      `json:"next_run_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+type ScheduledTransferRun struct {
+	ID                  int64         `json:"id"`
+	ScheduledTransferID int64         `json:"scheduled_transfer_id"`
+	ScheduledFor        time.Time     `json:"scheduled_for"`
+	Status              string        `json:"status"`
+	TransferID          sql.NullInt64 `json:"transfer_id"`
+	Error               string        `json:"error"`
+	CreatedAt           time.Time     `json:"created_at"`
 }
 
 type Session struct {
-	ID           uuid.UUID `json:"id"`
-	Username     string    `json:"username"`
-	RefreshToken string    `json:"refresh_token"`
-	UserAgent    string    `json:"user_agent"`
-	ClientIp     string    `json:"client_ip"`
-	IsBlocked    bool      `json:"is_blocked"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           uuid.UUID     `json:"id"`
+	Username     string        `json:"username"`
+	RefreshToken string        `json:"refresh_token"`
+	UserAgent    string        `json:"user_agent"`
+	ClientIp     string        `json:"client_ip"`
+	IsBlocked    bool          `json:"is_blocked"`
+	ExpiresAt    time.Time     `json:"expires_at"`
+	CreatedAt    time.Time     `json:"created_at"`
+	FamilyID     uuid.UUID     `json:"family_id"`
+	ReplacedBy   uuid.NullUUID `json:"replaced_by"`
+}
+
+type Task struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int32           `json:"attempts"`
+	MaxAttempts int32           `json:"max_attempts"`
+	LastError   string          `json:"last_error"`
+	RunAt       time.Time       `json:"run_at"`
+	LockedUntil sql.NullTime    `json:"locked_until"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
 }
 
 type Transfer struct {
-	ID            int64         `json:"id"`
-	FromAccountID int64         `json:"from_account_id"`
-	ToAccountID   int64         `json:"to_account_id"`
-	Amount        sql.NullInt64 `json:"amount"`
-	CreatedAt     time.Time     `json:"created_at"`
+	ID                 int64          `json:"id"`
+	FromAccountID      int64          `json:"from_account_id"`
+	ToAccountID        int64          `json:"to_account_id"`
+	Amount             int64          `json:"amount"`
+	CreatedAt          time.Time      `json:"created_at"`
+	ToAmount           sql.NullInt64  `json:"to_amount"`
+	ExchangeRate       sql.NullString `json:"exchange_rate"`
+	ReversedTransferID sql.NullInt64  `json:"reversed_transfer_id"`
+	Status             TransferStatus `json:"status"`
+	Fee                int64          `json:"fee"`
 }
 
 type User struct {
@@ -52,4 +258,16 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	Role              string    `json:"role"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
+}
+
+type VerifyEmail struct {
+	ID         int64     `json:"id"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	SecretCode string    `json:"secret_code"`
+	IsUsed     bool      `json:"is_used"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
 }
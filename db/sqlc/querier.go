@@ -6,29 +6,126 @@ package db
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddAccountHeldAmount(ctx context.Context, arg AddAccountHeldAmountParams) (Account, error)
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error
+	// Scoped to the user so a session of another user is reported as not found
+	BlockUserSession(ctx context.Context, arg BlockUserSessionParams) (Session, error)
+	BlockUserSessions(ctx context.Context, username string) (int64, error)
+	// Locks the most overdue active scheduled transfer, rows locked by other schedulers are skipped
+	ClaimDueScheduledTransfer(ctx context.Context) (ScheduledTransfer, error)
+	// Locks the next due task, tasks locked by other workers are skipped instead of waited for
+	ClaimTask(ctx context.Context, lockedUntil sql.NullTime) (Task, error)
+	CompleteTask(ctx context.Context, id int64) error
+	// A personal account unless the type is set
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateCurrency(ctx context.Context, arg CreateCurrencyParams) (Currency, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateFeeRule(ctx context.Context, arg CreateFeeRuleParams) (FeeRule, error)
+	CreateHold(ctx context.Context, arg CreateHoldParams) (Hold, error)
+	// Claims the key, no row comes back if it was already claimed.
+	// A claim is never taken over: a request that didn't finish may still have committed,
+	// so its retries are rejected until the key is released or its response is saved.
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	// Moves no money until it is completed
+	CreatePendingTransfer(ctx context.Context, arg CreatePendingTransferParams) (Transfer, error)
+	CreateRate(ctx context.Context, arg CreateRateParams) (Rate, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
+	DeadLetterTask(ctx context.Context, arg DeadLetterTaskParams) error
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteFeeRule(ctx context.Context, id int64) error
+	// Releases the key of a failed request, only while the claim is still held by it.
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	ExpireHolds(ctx context.Context, ids []int64) error
 	GetAccount(ctx context.Context, id int64) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetCurrency(ctx context.Context, code string) (Currency, error)
+	// The latest rate of the pair that is effective at the given time
+	GetEffectiveRate(ctx context.Context, arg GetEffectiveRateParams) (Rate, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	// The most specific rule for the currency and account type, a currency rule wins over an account type rule
+	GetFeeRule(ctx context.Context, arg GetFeeRuleParams) (FeeRule, error)
+	GetHold(ctx context.Context, id int64) (Hold, error)
+	GetHoldForUpdate(ctx context.Context, id int64) (Hold, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	// The fee revenue account of the currency, created on first use and returned locked
+	GetOrCreateFeeAccount(ctx context.Context, currency string) (Account, error)
+	// The cash account of the currency, created on first use.
+	// The no-op update makes the conflicting row come back locked, like `GetAccountForUpdate`.
+	GetOrCreateSystemAccount(ctx context.Context, currency string) (Account, error)
+	// What the account transferred out since the start of the day and of the month.
+	// Pending transfers count as they will move the money, fees and reversals paid out of the account don't.
+	GetOutgoingTotals(ctx context.Context, arg GetOutgoingTotalsParams) (GetOutgoingTotalsRow, error)
+	// The part of the transfer already refunded, in the currency of its from account.
+	// That is the to amount of a cross-currency reversal.
+	GetReversedAmount(ctx context.Context, reversedTransferID sql.NullInt64) (int64, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetScheduledTransferForUpdate(ctx context.Context, id int64) (ScheduledTransfer, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetTask(ctx context.Context, id int64) (Task, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	// The limits of the currency and of the account, the account row comes last so it overrides the currency row
+	ListAccountLimits(ctx context.Context, arg ListAccountLimitsParams) ([]Limit, error)
+	// Keyset pagination, the next page starts after the last id of the previous page
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListActiveSessions(ctx context.Context, username string) ([]Session, error)
+	// Accounts whose balance is not the sum of their entries
+	ListBalanceMismatches(ctx context.Context) ([]ListBalanceMismatchesRow, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
+	// Currencies whose entries don't net to zero
+	ListCurrencyImbalances(ctx context.Context) ([]ListCurrencyImbalancesRow, error)
+	// Keyset pagination, the next page starts after the last id of the previous page.
+	// Filters are optional, a NULL filter matches every entry.
+	// The amount range is compared with the absolute amount, so it works for both directions.
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	// Locks a batch of active holds past their expiry, holds locked by a capture or release are skipped
+	ListLapsedHoldsForUpdate(ctx context.Context, limit int32) ([]Hold, error)
+	// Newest first
+	ListScheduledTransferRuns(ctx context.Context, arg ListScheduledTransferRunsParams) ([]ScheduledTransferRun, error)
+	// Cancelled scheduled transfers are left out.
+	// Keyset pagination, the next page starts after the last id of the previous page
+	ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error)
+	// Lists the transfers in and out of an account.
+	// Keyset pagination, the next page starts after the last id of the previous page.
+	// Filters are optional, a NULL filter matches every transfer.
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	// Transfers that don't have exactly one debit entry on the from account and one credit entry on the to account.
+	// A cross-currency transfer also has one entry on the system account of each currency,
+	// and a transfer with a fee has one more debit on the from account and a credit on the fee account.
+	// Transfers that were never completed must have no entries.
+	ListUnbalancedTransfers(ctx context.Context) ([]ListUnbalancedTransfersRow, error)
+	// Only an active session can be replaced, so no row is returned when it is already blocked or replaced
+	ReplaceSession(ctx context.Context, arg ReplaceSessionParams) (Session, error)
+	RetryTask(ctx context.Context, arg RetryTaskParams) error
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error)
+	UpdateCurrency(ctx context.Context, arg UpdateCurrencyParams) (Currency, error)
+	UpdateHoldStatus(ctx context.Context, arg UpdateHoldStatusParams) (Hold, error)
+	// Saves the response, no row comes back unless the claim is still held by the request.
+	UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) (IdempotencyKey, error)
+	// Completed and cancelled scheduled transfers are final, no row comes back for them
+	UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error)
+	// The conversion of a cross-currency transfer is only known once it is completed
+	UpdateTransferStatus(ctx context.Context, arg UpdateTransferStatusParams) (Transfer, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	// Only an unused and unexpired code can be used, otherwise no row is returned
+	UpdateVerifyEmail(ctx context.Context, arg UpdateVerifyEmailParams) (VerifyEmail, error)
+	UpsertLimit(ctx context.Context, arg UpsertLimitParams) (Limit, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,79 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: rate.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const createRate = `-- name: CreateRate :one
+INSERT INTO rates (
+  base_currency,
+  quote_currency,
+  rate,
+  effective_at
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING id, base_currency, quote_currency, rate, effective_at, created_at
+`
+
+type CreateRateParams struct {
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          string    `json:"rate"`
+	EffectiveAt   time.Time `json:"effective_at"`
+}
+
+func (q *Queries) CreateRate(ctx context.Context, arg CreateRateParams) (Rate, error) {
+	row := q.db.QueryRowContext(ctx, createRate,
+		arg.BaseCurrency,
+		arg.QuoteCurrency,
+		arg.Rate,
+		arg.EffectiveAt,
+	)
+	var i Rate
+	err := row.Scan(
+		&i.ID,
+		&i.BaseCurrency,
+		&i.QuoteCurrency,
+		&i.Rate,
+		&i.EffectiveAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getEffectiveRate = `-- name: GetEffectiveRate :one
+SELECT id, base_currency, quote_currency, rate, effective_at, created_at FROM rates
+WHERE
+  base_currency = $1 AND
+  quote_currency = $2 AND
+  effective_at <= $3
+ORDER BY effective_at DESC
+LIMIT 1
+`
+
+type GetEffectiveRateParams struct {
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	At            time.Time `json:"at"`
+}
+
+// The latest rate of the pair that is effective at the given time
+func (q *Queries) GetEffectiveRate(ctx context.Context, arg GetEffectiveRateParams) (Rate, error) {
+	row := q.db.QueryRowContext(ctx, getEffectiveRate, arg.BaseCurrency, arg.QuoteCurrency, arg.At)
+	var i Rate
+	err := row.Scan(
+		&i.ID,
+		&i.BaseCurrency,
+		&i.QuoteCurrency,
+		&i.Rate,
+		&i.EffectiveAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
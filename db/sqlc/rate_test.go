@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEffectiveRate(t *testing.T) {
+	requireDB(t)
+
+	// Rates far in the future are newer than any other rate of the pair in the table
+	start := time.Now().AddDate(100, 0, 0).Truncate(time.Second)
+
+	for i, value := range []string{"0.5", "0.75"} {
+		_, err := testQueries.CreateRate(context.Background(), CreateRateParams{
+			BaseCurrency:  util.CAD,
+			QuoteCurrency: util.INR,
+			Rate:          value,
+			EffectiveAt:   start.Add(time.Duration(i) * time.Hour),
+		})
+		require.NoError(t, err)
+	}
+
+	rate, err := testQueries.GetEffectiveRate(context.Background(), GetEffectiveRateParams{
+		BaseCurrency:  util.CAD,
+		QuoteCurrency: util.INR,
+		At:            start.Add(30 * time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "0.5", rate.Rate)
+
+	rate, err = testQueries.GetEffectiveRate(context.Background(), GetEffectiveRateParams{
+		BaseCurrency:  util.CAD,
+		QuoteCurrency: util.INR,
+		At:            start.Add(2 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "0.75", rate.Rate)
+
+	_, err = testQueries.GetEffectiveRate(context.Background(), GetEffectiveRateParams{
+		BaseCurrency:  util.INR,
+		QuoteCurrency: util.CAD,
+		At:            start.Add(2 * time.Hour),
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
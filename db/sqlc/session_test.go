@@ -0,0 +1,169 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func randomSessionParams(username string) CreateSessionParams {
+	id := uuid.New()
+	return CreateSessionParams{
+		ID:           id,
+		Username:     username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    "test-agent",
+		ClientIp:     "127.0.0.1",
+		IsBlocked:    false,
+		ExpiresAt:    time.Now().Add(time.Hour),
+		FamilyID:     id,
+	}
+}
+
+func renewSessionParams(oldSession Session) RenewSessionTxParams {
+	arg := RenewSessionTxParams{
+		OldSessionID: oldSession.ID,
+		NewSession:   randomSessionParams(oldSession.Username),
+	}
+	arg.NewSession.FamilyID = oldSession.FamilyID
+	return arg
+}
+
+func createRandomSession(t *testing.T, username string) Session {
+	arg := randomSessionParams(username)
+
+	session, err := testQueries.CreateSession(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, session.ID)
+	require.Equal(t, arg.Username, session.Username)
+	require.False(t, session.IsBlocked)
+
+	return session
+}
+
+func TestRenewSessionTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	oldSession := createRandomSession(t, user.Username)
+
+	arg := renewSessionParams(oldSession)
+
+	newSession, err := store.RenewSessionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.NewSession.ID, newSession.ID)
+	require.False(t, newSession.IsBlocked)
+	require.Equal(t, oldSession.FamilyID, newSession.FamilyID)
+	require.False(t, newSession.ReplacedBy.Valid)
+
+	oldSession, err = testQueries.GetSession(context.Background(), oldSession.ID)
+	require.NoError(t, err)
+	require.True(t, oldSession.IsBlocked)
+	require.Equal(t, newSession.ID, oldSession.ReplacedBy.UUID)
+
+	// The old refresh token can't be renewed again
+	_, err = store.RenewSessionTx(context.Background(), renewSessionParams(oldSession))
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+}
+
+func TestRenewBlockedSessionTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+
+	err := testQueries.BlockSessionFamily(context.Background(), session.FamilyID)
+	require.NoError(t, err)
+
+	_, err = store.RenewSessionTx(context.Background(), renewSessionParams(session))
+	require.ErrorIs(t, err, ErrSessionBlocked)
+}
+
+func TestBlockSessionFamily(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	firstSession := createRandomSession(t, user.Username)
+	otherSession := createRandomSession(t, user.Username)
+
+	secondSession, err := store.RenewSessionTx(context.Background(), renewSessionParams(firstSession))
+	require.NoError(t, err)
+
+	err = testQueries.BlockSessionFamily(context.Background(), firstSession.FamilyID)
+	require.NoError(t, err)
+
+	secondSession, err = testQueries.GetSession(context.Background(), secondSession.ID)
+	require.NoError(t, err)
+	require.True(t, secondSession.IsBlocked)
+
+	// Sessions from other logins are not affected
+	otherSession, err = testQueries.GetSession(context.Background(), otherSession.ID)
+	require.NoError(t, err)
+	require.False(t, otherSession.IsBlocked)
+}
+
+func TestListActiveSessions(t *testing.T) {
+	requireDB(t)
+
+	user := createRandomUser(t)
+	activeSession := createRandomSession(t, user.Username)
+	blockedSession := createRandomSession(t, user.Username)
+
+	_, err := testQueries.BlockUserSession(context.Background(), BlockUserSessionParams{
+		ID:       blockedSession.ID,
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+
+	sessions, err := testQueries.ListActiveSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, activeSession.ID, sessions[0].ID)
+}
+
+func TestBlockUserSession(t *testing.T) {
+	requireDB(t)
+
+	user := createRandomUser(t)
+	otherUser := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+
+	// A session can't be blocked by another user
+	_, err := testQueries.BlockUserSession(context.Background(), BlockUserSessionParams{
+		ID:       session.ID,
+		Username: otherUser.Username,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	session, err = testQueries.BlockUserSession(context.Background(), BlockUserSessionParams{
+		ID:       session.ID,
+		Username: user.Username,
+	})
+	require.NoError(t, err)
+	require.True(t, session.IsBlocked)
+}
+
+func TestBlockUserSessions(t *testing.T) {
+	requireDB(t)
+
+	user := createRandomUser(t)
+	for i := 0; i < 3; i++ {
+		createRandomSession(t, user.Username)
+	}
+
+	revoked, err := testQueries.BlockUserSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), revoked)
+
+	sessions, err := testQueries.ListActiveSessions(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
@@ -3,27 +3,56 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"simple-bank/util"
 )
 
+// Returned by `TransferTx` when the available balance of the from account would go below its overdraft limit
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	CrossCurrencyTransferTx(ctx context.Context, arg CrossCurrencyTransferTxParams) (TransferTxResult, error)
+	RenewSessionTx(ctx context.Context, arg RenewSessionTxParams) (Session, error)
+	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error)
+	DepositTx(ctx context.Context, arg DepositTxParams) (CashTxResult, error)
+	WithdrawTx(ctx context.Context, arg WithdrawTxParams) (CashTxResult, error)
+	ExecuteScheduledTransferTx(ctx context.Context, arg ExecuteScheduledTransferTxParams) (ExecuteScheduledTransferTxResult, error)
+	PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (PlaceHoldTxResult, error)
+	CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error)
+	ReleaseHoldTx(ctx context.Context, arg ReleaseHoldTxParams) (ReleaseHoldTxResult, error)
+	ExpireHoldsTx(ctx context.Context, limit int32) (int64, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	CompleteTransferTx(ctx context.Context, arg CompleteTransferTxParams) (TransferTxResult, error)
+	CancelTransferTx(ctx context.Context, arg CancelTransferTxParams) (Transfer, error)
+	GetTransferLimits(ctx context.Context, account Account) (TransferLimits, error)
+	GetTransferFee(ctx context.Context, account Account, amount int64) (int64, error)
 }
 
 // Provides all functions to execute db queries and transactions
 type SQLStore struct {
-	*Queries // From `db.go`
-	db       *sql.DB
+	*Queries      // From `db.go`
+	db            *sql.DB
+	defaultLimits TransferLimits
 }
 
 // Cannot return `*Store` as `*SQLStore` does not implement `*Store` interface
 // This is because interfaces are already references
-func NewStore(db *sql.DB) Store {
-	return &SQLStore{
+func NewStore(db *sql.DB, opts ...StoreOption) Store {
+	store := &SQLStore{
 		Queries: New(db),
 		db:      db,
 	}
+
+	for _, opt := range opts {
+		opt(store)
+	}
+
+	return store
 }
 
 func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
@@ -63,6 +92,8 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// The debit of the fee on the from account, only set when the transfer has a fee
+	FeeEntry Entry `json:"fee_entry"`
 }
 
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
@@ -70,41 +101,109 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = store.transfer(ctx, q, arg)
+		return err
+	})
 
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        sql.NullInt64{Int64: arg.Amount, Valid: true},
-		})
-		if err != nil {
-			return err
-		}
+	return result, err
+}
 
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
+// The writes of `TransferTx`, for transactions that make a transfer as one of their steps.
+// The from account is charged the fee of its fee rule on top of the amount.
+// Fails with `ErrLimitExceeded` if the transfer goes over a limit of the from account.
+func (store *SQLStore) transfer(ctx context.Context, q *Queries, arg TransferTxParams) (TransferTxResult, error) {
+	// The rule only depends on the currency and type of the account, which never change
+	fromAccount, err := q.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+
+	fee, err := transferFee(ctx, q, fromAccount, arg.Amount)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+
+	result, err := bookTransfer(ctx, q, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Fee:           fee,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	return result, store.checkTransferLimits(ctx, q, result.FromAccount, arg.Amount)
+}
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+// Records a completed same currency transfer with its entries
+func bookTransfer(ctx context.Context, q *Queries, arg CreateTransferParams) (TransferTxResult, error) {
+	transfer, err := q.CreateTransfer(ctx, arg)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+
+	return bookEntries(ctx, q, transfer)
+}
+
+// Records the two entries and the balance updates of a same currency transfer, and its fee if it has one
+func bookEntries(ctx context.Context, q *Queries, transfer Transfer) (TransferTxResult, error) {
+	result := TransferTxResult{Transfer: transfer}
+	var err error
+
+	arg := TransferTxParams{
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+	}
+
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.FromAccountID,
+		Amount:     -arg.Amount,
+		TransferID: sql.NullInt64{Int64: transfer.ID, Valid: true},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.ToAccountID,
+		Amount:     arg.Amount,
+		TransferID: sql.NullInt64{Int64: transfer.ID, Valid: true},
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if transfer.Fee > 0 {
+		result.FeeEntry, result.FromAccount, err = bookFee(ctx, q, transfer, result.FromAccount.Currency)
 		if err != nil {
-			return err
+			return result, err
 		}
+	}
 
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
-		}
-		return nil
-	})
+	// The balance is checked after the update, so it is the value under the row lock.
+	// Returning an error rolls back the transfer, both entries and both balance updates.
+	return result, checkAvailableBalance(result.FromAccount)
+}
 
-	return result, err
+// Returns `ErrInsufficientFunds` if the available balance of the account, the part not reserved by holds,
+// is below its overdraft limit. The account must have been read under its row lock.
+func checkAvailableBalance(account Account) error {
+	if account.Balance-account.HeldAmount < -account.OverdraftLimit {
+		return fmt.Errorf("%w: account [%d] available balance cannot go below %s",
+			ErrInsufficientFunds, account.ID, util.Money{Amount: -account.OverdraftLimit, Currency: account.Currency})
+	}
+	return nil
 }
 
 func addMoney(
@@ -11,8 +11,9 @@ func TestTransfer(t *testing.T) {
 	requireDB(t)
 	store := NewStore(testDB)
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	// Enough balance for every transfer, so none of them overdraws the account
+	account1 := fundAccount(t, createRandomAccount(t), 1000)
+	account2 := fundAccount(t, createRandomAccount(t), 1000)
 
 	// Run "n" concurrenct transfers
 	n := 5
@@ -50,7 +51,7 @@ func TestTransfer(t *testing.T) {
 		require.NotEmpty(t, transfer)
 		require.Equal(t, account1.ID, transfer.FromAccountID)
 		require.Equal(t, account2.ID, transfer.ToAccountID)
-		require.Equal(t, amount, transfer.Amount.Int64)
+		require.Equal(t, amount, transfer.Amount)
 		require.NotEmpty(t, transfer.ID)
 		require.NotEmpty(t, transfer.CreatedAt)
 
@@ -62,6 +63,7 @@ func TestTransfer(t *testing.T) {
 		require.NotEmpty(t, fromEntry)
 		require.Equal(t, account1.ID, fromEntry.AccountID)
 		require.Equal(t, -amount, fromEntry.Amount)
+		require.Equal(t, transfer.ID, fromEntry.TransferID.Int64)
 		require.NotEmpty(t, fromEntry.ID)
 		require.NotEmpty(t, fromEntry.CreatedAt)
 
@@ -73,6 +75,7 @@ func TestTransfer(t *testing.T) {
 		require.NotEmpty(t, toEntry)
 		require.Equal(t, account2.ID, toEntry.AccountID)
 		require.Equal(t, amount, toEntry.Amount)
+		require.Equal(t, transfer.ID, toEntry.TransferID.Int64)
 		require.NotEmpty(t, toEntry.ID)
 		require.NotEmpty(t, toEntry.CreatedAt)
 
@@ -116,8 +119,9 @@ func TestTransferDeadlock(t *testing.T) {
 	requireDB(t)
 	store := NewStore(testDB)
 
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
+	// Enough balance for every transfer, so none of them overdraws the account
+	account1 := fundAccount(t, createRandomAccount(t), 1000)
+	account2 := fundAccount(t, createRandomAccount(t), 1000)
 
 	n := 10
 	amount := int64(10)
@@ -163,3 +167,65 @@ func TestTransferDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestTransferInsufficientFunds(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := fundAccount(t, createRandomAccount(t), 10)
+	account2 := createRandomAccount(t)
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        11,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// The whole transaction is rolled back
+	updatedAccount1, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	updatedAccount2, err := testQueries.GetAccount(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+}
+
+func TestTransferWithinOverdraftLimit(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := fundAccount(t, createRandomAccount(t), 10)
+	account2 := createRandomAccount(t)
+
+	account1, err := testQueries.UpdateAccountOverdraftLimit(context.Background(), UpdateAccountOverdraftLimitParams{
+		ID:             account1.ID,
+		OverdraftLimit: 50,
+	})
+	require.NoError(t, err)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        60,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(-50), result.FromAccount.Balance)
+
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func fundAccount(t *testing.T, account Account, balance int64) Account {
+	account, err := testQueries.UpdateAccount(context.Background(), UpdateAccountParams{
+		ID:      account.ID,
+		Balance: balance,
+	})
+	require.NoError(t, err)
+	return account
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTask(t *testing.T, runAt time.Time) Task {
+	arg := CreateTaskParams{
+		Type:        "task:test",
+		Payload:     []byte(`{"username":"alice"}`),
+		MaxAttempts: 3,
+		RunAt:       runAt,
+	}
+
+	task, err := testQueries.CreateTask(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Type, task.Type)
+	require.JSONEq(t, string(arg.Payload), string(task.Payload))
+	require.Equal(t, "pending", task.Status)
+	require.Zero(t, task.Attempts)
+
+	return task
+}
+
+func TestClaimTask(t *testing.T) {
+	requireDB(t)
+
+	// Due before any task left over by other tests
+	task := createRandomTask(t, time.Now().Add(-24*time.Hour))
+	lockedUntil := sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true}
+
+	claimed, err := testQueries.ClaimTask(context.Background(), lockedUntil)
+	require.NoError(t, err)
+	require.Equal(t, task.ID, claimed.ID)
+	require.Equal(t, "running", claimed.Status)
+	require.Equal(t, int32(1), claimed.Attempts)
+
+	// A running task isn't claimed again while its lease is valid
+	other, err := testQueries.ClaimTask(context.Background(), lockedUntil)
+	if err != sql.ErrNoRows {
+		require.NoError(t, err)
+		require.NotEqual(t, task.ID, other.ID)
+	}
+
+	err = testQueries.CompleteTask(context.Background(), task.ID)
+	require.NoError(t, err)
+
+	task, err = testQueries.GetTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	require.Equal(t, "completed", task.Status)
+	require.False(t, task.LockedUntil.Valid)
+}
+
+func TestClaimTaskExpiredLease(t *testing.T) {
+	requireDB(t)
+
+	task := createRandomTask(t, time.Now().Add(-48*time.Hour))
+
+	// The worker that claimed the task never finished it
+	expired := sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+	claimed, err := testQueries.ClaimTask(context.Background(), expired)
+	require.NoError(t, err)
+	require.Equal(t, task.ID, claimed.ID)
+
+	claimed, err = testQueries.ClaimTask(context.Background(), sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true})
+	require.NoError(t, err)
+	require.Equal(t, task.ID, claimed.ID)
+	require.Equal(t, int32(2), claimed.Attempts)
+
+	err = testQueries.DeadLetterTask(context.Background(), DeadLetterTaskParams{
+		ID:        task.ID,
+		LastError: "gave up",
+	})
+	require.NoError(t, err)
+
+	task, err = testQueries.GetTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	require.Equal(t, "dead", task.Status)
+	require.Equal(t, "gave up", task.LastError)
+}
+
+func TestRetryTask(t *testing.T) {
+	requireDB(t)
+
+	task := createRandomTask(t, time.Now().Add(time.Hour))
+	runAt := time.Now().Add(2 * time.Hour)
+
+	err := testQueries.RetryTask(context.Background(), RetryTaskParams{
+		ID:        task.ID,
+		RunAt:     runAt,
+		LastError: "try again",
+	})
+	require.NoError(t, err)
+
+	task, err = testQueries.GetTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	require.Equal(t, "pending", task.Status)
+	require.Equal(t, "try again", task.LastError)
+	require.WithinDuration(t, runAt, task.RunAt, time.Second)
+}
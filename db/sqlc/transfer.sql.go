@@ -10,24 +10,83 @@ import (
 	"database/sql"
 )
 
+const createPendingTransfer = `-- name: CreatePendingTransfer :one
+INSERT INTO transfers (
+  from_account_id,
+  to_account_id,
+  amount,
+  fee,
+  status
+) VALUES (
+  $1, $2, $3, $4, 'pending'
+) RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversed_transfer_id, status, fee
+`
+
+type CreatePendingTransferParams struct {
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	Amount        int64 `json:"amount"`
+	Fee           int64 `json:"fee"`
+}
+
+// Moves no money until it is completed
+func (q *Queries) CreatePendingTransfer(ctx context.Context, arg CreatePendingTransferParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, createPendingTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Fee,
+	)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversedTransferID,
+		&i.Status,
+		&i.Fee,
+	)
+	return i, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  to_amount,
+  exchange_rate,
+  reversed_transfer_id,
+  fee
 ) VALUES (
-  $1, $2, $3
-) RETURNING id, from_account_id, to_account_id, amount, created_at
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversed_transfer_id, status, fee
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64         `json:"from_account_id"`
-	ToAccountID   int64         `json:"to_account_id"`
-	Amount        sql.NullInt64 `json:"amount"`
+	FromAccountID      int64          `json:"from_account_id"`
+	ToAccountID        int64          `json:"to_account_id"`
+	Amount             int64          `json:"amount"`
+	ToAmount           sql.NullInt64  `json:"to_amount"`
+	ExchangeRate       sql.NullString `json:"exchange_rate"`
+	ReversedTransferID sql.NullInt64  `json:"reversed_transfer_id"`
+	Fee                int64          `json:"fee"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.ExchangeRate,
+		arg.ReversedTransferID,
+		arg.Fee,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -35,12 +94,32 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversedTransferID,
+		&i.Status,
+		&i.Fee,
 	)
 	return i, err
 }
 
+const getReversedAmount = `-- name: GetReversedAmount :one
+SELECT COALESCE(SUM(COALESCE(to_amount, amount)), 0)::bigint AS reversed_amount
+FROM transfers
+WHERE reversed_transfer_id = $1
+`
+
+// The part of the transfer already refunded, in the currency of its from account.
+// That is the to amount of a cross-currency reversal.
+func (q *Queries) GetReversedAmount(ctx context.Context, reversedTransferID sql.NullInt64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getReversedAmount, reversedTransferID)
+	var reversed_amount int64
+	err := row.Scan(&reversed_amount)
+	return reversed_amount, err
+}
+
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversed_transfer_id, status, fee FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -53,33 +132,83 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversedTransferID,
+		&i.Status,
+		&i.Fee,
+	)
+	return i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversed_transfer_id, status, fee FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversedTransferID,
+		&i.Status,
+		&i.Fee,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
-WHERE 
-    from_account_id = $1 OR
-    to_account_id = $2
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversed_transfer_id, status, fee FROM transfers
+WHERE
+  (CASE $1::varchar
+    WHEN 'incoming' THEN to_account_id = $2
+    WHEN 'outgoing' THEN from_account_id = $2
+    ELSE from_account_id = $2 OR to_account_id = $2
+  END) AND
+  id > $3 AND
+  ($4::timestamptz IS NULL OR created_at >= $4::timestamptz) AND
+  ($5::timestamptz IS NULL OR created_at < $5::timestamptz) AND
+  ($6::bigint IS NULL OR amount >= $6::bigint) AND
+  ($7::bigint IS NULL OR amount <= $7::bigint) AND
+  ($8::transfer_status IS NULL OR status = $8::transfer_status)
 ORDER BY id
-LIMIT $3
-OFFSET $4
+LIMIT $9
 `
 
 type ListTransfersParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Limit         int32 `json:"limit"`
-	Offset        int32 `json:"offset"`
+	Direction sql.NullString     `json:"direction"`
+	AccountID int64              `json:"account_id"`
+	AfterID   int64              `json:"after_id"`
+	StartTime sql.NullTime       `json:"start_time"`
+	EndTime   sql.NullTime       `json:"end_time"`
+	MinAmount sql.NullInt64      `json:"min_amount"`
+	MaxAmount sql.NullInt64      `json:"max_amount"`
+	Status    NullTransferStatus `json:"status"`
+	Limit     int32              `json:"limit"`
 }
 
+// Lists the transfers in and out of an account.
+// Keyset pagination, the next page starts after the last id of the previous page.
+// Filters are optional, a NULL filter matches every transfer.
 func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
 	rows, err := q.db.QueryContext(ctx, listTransfers,
-		arg.FromAccountID,
-		arg.ToAccountID,
+		arg.Direction,
+		arg.AccountID,
+		arg.AfterID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.Status,
 		arg.Limit,
-		arg.Offset,
 	)
 	if err != nil {
 		return nil, err
@@ -94,6 +223,11 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ToAmount,
+			&i.ExchangeRate,
+			&i.ReversedTransferID,
+			&i.Status,
+			&i.Fee,
 		); err != nil {
 			return nil, err
 		}
@@ -107,3 +241,44 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	}
 	return items, nil
 }
+
+const updateTransferStatus = `-- name: UpdateTransferStatus :one
+UPDATE transfers
+SET
+  status = $1,
+  to_amount = COALESCE($2, to_amount),
+  exchange_rate = COALESCE($3, exchange_rate)
+WHERE id = $4
+RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, reversed_transfer_id, status, fee
+`
+
+type UpdateTransferStatusParams struct {
+	Status       TransferStatus `json:"status"`
+	ToAmount     sql.NullInt64  `json:"to_amount"`
+	ExchangeRate sql.NullString `json:"exchange_rate"`
+	ID           int64          `json:"id"`
+}
+
+// The conversion of a cross-currency transfer is only known once it is completed
+func (q *Queries) UpdateTransferStatus(ctx context.Context, arg UpdateTransferStatusParams) (Transfer, error) {
+	row := q.db.QueryRowContext(ctx, updateTransferStatus,
+		arg.Status,
+		arg.ToAmount,
+		arg.ExchangeRate,
+		arg.ID,
+	)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.ReversedTransferID,
+		&i.Status,
+		&i.Fee,
+	)
+	return i, err
+}
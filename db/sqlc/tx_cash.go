@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+// Owner of the per-currency system accounts, the other side of deposits, withdrawals and conversions.
+// The user is created by the `add_system_accounts` migration.
+const SystemUsername = "system"
+
+// Cash only moves between customer accounts and the system accounts, fee revenue is only booked by transfers
+var ErrHouseAccount = errors.New("cannot deposit to or withdraw from a house account")
+
+type DepositTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+}
+
+type WithdrawTxParams struct {
+	AccountID int64 `json:"account_id"`
+	Amount    int64 `json:"amount"`
+}
+
+type CashTxResult struct {
+	Account     Account `json:"account"`
+	CashAccount Account `json:"cash_account"`
+	Entry       Entry   `json:"entry"`
+	CashEntry   Entry   `json:"cash_entry"`
+}
+
+// Credits the account, the money comes from the cash account of its currency
+func (store *SQLStore) DepositTx(ctx context.Context, arg DepositTxParams) (CashTxResult, error) {
+	return store.cashTx(ctx, arg.AccountID, arg.Amount)
+}
+
+// Debits the account into the cash account of its currency.
+// Fails with `ErrInsufficientFunds` if the available balance would go below the overdraft limit.
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg WithdrawTxParams) (CashTxResult, error) {
+	return store.cashTx(ctx, arg.AccountID, -arg.Amount)
+}
+
+// Books `amount` on the account and the opposite amount on the cash account,
+// so each currency still nets to zero. A negative amount is a withdrawal.
+func (store *SQLStore) cashTx(ctx context.Context, accountID int64, amount int64) (CashTxResult, error) {
+	var result CashTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// Every cash transaction locks the account before the cash account
+		account, err := q.GetAccountForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if IsHouseAccount(account) {
+			return ErrHouseAccount
+		}
+
+		result.CashAccount, err = q.GetOrCreateSystemAccount(ctx, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		result.Entry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: account.ID,
+			Amount:    amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.CashEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: result.CashAccount.ID,
+			Amount:    -amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, result.CashAccount, err = addMoney(ctx, q, account.ID, amount, result.CashAccount.ID, -amount)
+		if err != nil {
+			return err
+		}
+
+		if amount < 0 {
+			return checkAvailableBalance(result.Account)
+		}
+		return nil
+	})
+
+	return result, err
+}
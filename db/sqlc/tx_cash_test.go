@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account := createRandomAccount(t)
+	cashAccount, err := testQueries.GetOrCreateSystemAccount(context.Background(), account.Currency)
+	require.NoError(t, err)
+
+	result, err := store.DepositTx(context.Background(), DepositTxParams{
+		AccountID: account.ID,
+		Amount:    10,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, account.Balance+10, result.Account.Balance)
+	require.Equal(t, SystemUsername, result.CashAccount.Owner)
+	require.Equal(t, account.Currency, result.CashAccount.Currency)
+
+	require.Equal(t, account.ID, result.Entry.AccountID)
+	require.Equal(t, int64(10), result.Entry.Amount)
+	require.Equal(t, result.CashAccount.ID, result.CashEntry.AccountID)
+	require.Equal(t, int64(-10), result.CashEntry.Amount)
+
+	require.Equal(t, cashAccount.ID, result.CashAccount.ID)
+	require.Equal(t, cashAccount.Balance-10, result.CashAccount.Balance)
+}
+
+func TestWithdrawTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account := fundAccount(t, createRandomAccount(t), 10)
+
+	result, err := store.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    10,
+	})
+	require.NoError(t, err)
+
+	require.Zero(t, result.Account.Balance)
+	require.Equal(t, int64(-10), result.Entry.Amount)
+	require.Equal(t, int64(10), result.CashEntry.Amount)
+}
+
+func TestWithdrawTxInsufficientFunds(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account := fundAccount(t, createRandomAccount(t), 10)
+
+	_, err := store.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: account.ID,
+		Amount:    11,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// The whole transaction is rolled back
+	updatedAccount, err := testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, updatedAccount.Balance)
+}
+
+func TestDepositTxSystemAccount(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	cashAccount, err := testQueries.GetOrCreateSystemAccount(context.Background(), createRandomAccount(t).Currency)
+	require.NoError(t, err)
+
+	_, err = store.DepositTx(context.Background(), DepositTxParams{
+		AccountID: cashAccount.ID,
+		Amount:    10,
+	})
+	require.ErrorIs(t, err, ErrHouseAccount)
+}
+
+func TestWithdrawTxFeeAccount(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	feeAccount, err := testQueries.GetOrCreateFeeAccount(context.Background(), createRandomAccount(t).Currency)
+	require.NoError(t, err)
+
+	_, err = store.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: feeAccount.ID,
+		Amount:    1,
+	})
+	require.ErrorIs(t, err, ErrHouseAccount)
+
+	// Nothing is booked on the fee account
+	updatedFeeAccount, err := testQueries.GetAccount(context.Background(), feeAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, feeAccount.Balance, updatedFeeAccount.Balance)
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"simple-bank/util"
+)
+
+type CreateUserTxParams struct {
+	CreateUserParams
+	// Runs inside the transaction after the user is inserted, an error rolls back the user.
+	// Follow-up writes must go through `q` to be part of the transaction.
+	AfterCreate func(q Querier, user User) error
+}
+
+type CreateUserTxResult struct {
+	User User
+}
+
+// Creates a user and runs `AfterCreate` in the same transaction
+func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error) {
+	var result CreateUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.User, err = q.CreateUser(ctx, arg.CreateUserParams)
+		if err != nil {
+			return err
+		}
+
+		if arg.AfterCreate == nil {
+			return nil
+		}
+		return arg.AfterCreate(q, result.User)
+	})
+
+	return result, err
+}
+
+// Creates a zero-balance account for `owner` in each enabled currency.
+// Repeated currencies are skipped so the `owner_currency_key` constraint never fails the transaction.
+func CreateDefaultAccounts(ctx context.Context, q Querier, owner string, currencies []string) ([]Account, error) {
+	seen := make(map[string]bool, len(currencies))
+	accounts := make([]Account, 0, len(currencies))
+
+	for _, currency := range currencies {
+		// A disabled currency must not block signups
+		if seen[currency] || !util.IsSupportedCurrency(currency) {
+			continue
+		}
+		seen[currency] = true
+
+		account, err := q.CreateAccount(ctx, CreateAccountParams{
+			Owner:    owner,
+			Balance:  0,
+			Currency: currency,
+		})
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"simple-bank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomCreateUserParams() CreateUserParams {
+	return CreateUserParams{
+		Username:       util.RandomOwner(),
+		HashedPassword: "secret",
+		FullName:       util.RandomOwner(),
+		Email:          util.RandomEmail(),
+	}
+}
+
+func TestCreateUserTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	var account Account
+	arg := CreateUserTxParams{
+		CreateUserParams: randomCreateUserParams(),
+		// Inserting a row that references the user only works inside the transaction
+		AfterCreate: func(q Querier, user User) error {
+			var err error
+			account, err = q.CreateAccount(context.Background(), CreateAccountParams{
+				Owner:    user.Username,
+				Balance:  0,
+				Currency: util.USD,
+			})
+			return err
+		},
+	}
+
+	result, err := store.CreateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Username, result.User.Username)
+
+	account, err = testQueries.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.User.Username, account.Owner)
+}
+
+func TestCreateUserTxRollback(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	errHook := errors.New("hook failed")
+	arg := CreateUserTxParams{
+		CreateUserParams: randomCreateUserParams(),
+		AfterCreate: func(q Querier, user User) error {
+			return errHook
+		},
+	}
+
+	_, err := store.CreateUserTx(context.Background(), arg)
+	require.ErrorIs(t, err, errHook)
+
+	// The user insert is rolled back with the failed hook
+	_, err = testQueries.GetUser(context.Background(), arg.Username)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCreateDefaultAccounts(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	var accounts []Account
+	arg := CreateUserTxParams{
+		CreateUserParams: randomCreateUserParams(),
+		// The repeated currency would violate `owner_currency_key` if it wasn't skipped
+		AfterCreate: func(q Querier, user User) error {
+			var err error
+			accounts, err = CreateDefaultAccounts(context.Background(), q, user.Username, []string{util.USD, util.EUR, util.USD})
+			return err
+		},
+	}
+
+	result, err := store.CreateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+
+	for i, currency := range []string{util.USD, util.EUR} {
+		account, err := testQueries.GetAccount(context.Background(), accounts[i].ID)
+		require.NoError(t, err)
+		require.Equal(t, result.User.Username, account.Owner)
+		require.Equal(t, currency, account.Currency)
+		require.Zero(t, account.Balance)
+	}
+}
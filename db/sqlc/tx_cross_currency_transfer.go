@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var ErrSameCurrency = errors.New("accounts have the same currency, use a regular transfer")
+
+type CrossCurrencyTransferTxParams struct {
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	// In the currency of the from account
+	Amount int64 `json:"amount"`
+	// In the currency of the to account, `Amount` converted with `ExchangeRate`
+	ToAmount int64 `json:"to_amount"`
+	// Decimal number of to currency units one from currency unit is worth
+	ExchangeRate string `json:"exchange_rate"`
+}
+
+// Moves `Amount` out of the from account and `ToAmount` into the to account.
+// The system account of each currency takes the other side of the conversion,
+// so every currency still nets to zero.
+func (store *SQLStore) CrossCurrencyTransferTx(ctx context.Context, arg CrossCurrencyTransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		// The accounts are locked in id order like in `TransferTx`, the system accounts after them
+		if arg.FromAccountID < arg.ToAccountID {
+			result.FromAccount, result.ToAccount, err = lockAccounts(ctx, q, arg.FromAccountID, arg.ToAccountID)
+		} else {
+			result.ToAccount, result.FromAccount, err = lockAccounts(ctx, q, arg.ToAccountID, arg.FromAccountID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if result.FromAccount.Currency == result.ToAccount.Currency {
+			return ErrSameCurrency
+		}
+
+		// The fee is in the currency of the from account, like the amount
+		fee, err := transferFee(ctx, q, result.FromAccount, arg.Amount)
+		if err != nil {
+			return err
+		}
+
+		result, err = bookConversion(ctx, q, CreateTransferParams{
+			FromAccountID: arg.FromAccountID,
+			ToAccountID:   arg.ToAccountID,
+			Amount:        arg.Amount,
+			ToAmount:      sql.NullInt64{Int64: arg.ToAmount, Valid: true},
+			ExchangeRate:  sql.NullString{String: arg.ExchangeRate, Valid: true},
+			Fee:           fee,
+		}, result.FromAccount.Currency, result.ToAccount.Currency)
+		if err != nil {
+			return err
+		}
+
+		return store.checkTransferLimits(ctx, q, result.FromAccount, arg.Amount)
+	})
+
+	return result, err
+}
+
+// Records a completed cross-currency transfer with its entries.
+// The accounts must already be locked, the system accounts are locked after them.
+func bookConversion(ctx context.Context, q *Queries, arg CreateTransferParams, fromCurrency string, toCurrency string) (TransferTxResult, error) {
+	transfer, err := q.CreateTransfer(ctx, arg)
+	if err != nil {
+		return TransferTxResult{}, err
+	}
+
+	return bookConversionEntries(ctx, q, transfer, fromCurrency, toCurrency)
+}
+
+// Records the four entries and the balance updates of a cross-currency transfer, and its fee if it has one
+func bookConversionEntries(ctx context.Context, q *Queries, transfer Transfer, fromCurrency string, toCurrency string) (TransferTxResult, error) {
+	result := TransferTxResult{Transfer: transfer}
+
+	arg := CrossCurrencyTransferTxParams{
+		FromAccountID: transfer.FromAccountID,
+		ToAccountID:   transfer.ToAccountID,
+		Amount:        transfer.Amount,
+		ToAmount:      transfer.ToAmount.Int64,
+	}
+
+	fromSystemAccount, toSystemAccount, err := lockSystemAccounts(ctx, q, fromCurrency, toCurrency)
+	if err != nil {
+		return result, err
+	}
+
+	// Every entry of the transfer records the conversion it is part of
+	entry := func(accountID int64, amount int64) (Entry, error) {
+		return q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:    accountID,
+			Amount:       amount,
+			TransferID:   sql.NullInt64{Int64: result.Transfer.ID, Valid: true},
+			FromAmount:   sql.NullInt64{Int64: result.Transfer.Amount, Valid: true},
+			ToAmount:     result.Transfer.ToAmount,
+			ExchangeRate: result.Transfer.ExchangeRate,
+		})
+	}
+
+	result.FromEntry, err = entry(arg.FromAccountID, -arg.Amount)
+	if err != nil {
+		return result, err
+	}
+	if _, err = entry(fromSystemAccount.ID, arg.Amount); err != nil {
+		return result, err
+	}
+	if _, err = entry(toSystemAccount.ID, -arg.ToAmount); err != nil {
+		return result, err
+	}
+	result.ToEntry, err = entry(arg.ToAccountID, arg.ToAmount)
+	if err != nil {
+		return result, err
+	}
+
+	result.FromAccount, _, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, fromSystemAccount.ID, arg.Amount)
+	if err != nil {
+		return result, err
+	}
+	result.ToAccount, _, err = addMoney(ctx, q, arg.ToAccountID, arg.ToAmount, toSystemAccount.ID, -arg.ToAmount)
+	if err != nil {
+		return result, err
+	}
+
+	if transfer.Fee > 0 {
+		result.FeeEntry, result.FromAccount, err = bookFee(ctx, q, transfer, fromCurrency)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, checkAvailableBalance(result.FromAccount)
+}
+
+func lockAccounts(ctx context.Context, q *Queries, accountID1 int64, accountID2 int64) (account1 Account, account2 Account, err error) {
+	account1, err = q.GetAccountForUpdate(ctx, accountID1)
+	if err != nil {
+		return
+	}
+
+	account2, err = q.GetAccountForUpdate(ctx, accountID2)
+	return
+}
+
+// Locks the system accounts in currency order, so concurrent conversions can't deadlock on them
+func lockSystemAccounts(ctx context.Context, q *Queries, currency1 string, currency2 string) (account1 Account, account2 Account, err error) {
+	if currency1 > currency2 {
+		account2, account1, err = lockSystemAccounts(ctx, q, currency2, currency1)
+		return
+	}
+
+	account1, err = q.GetOrCreateSystemAccount(ctx, currency1)
+	if err != nil {
+		return
+	}
+
+	account2, err = q.GetOrCreateSystemAccount(ctx, currency2)
+	return
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"simple-bank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createAccountWithCurrency(t *testing.T, currency string, balance int64) Account {
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Balance:  balance,
+		Currency: currency,
+	})
+	require.NoError(t, err)
+	return account
+}
+
+func TestCrossCurrencyTransferTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD, 100)
+	account2 := createAccountWithCurrency(t, util.EUR, 0)
+
+	result, err := store.CrossCurrencyTransferTx(context.Background(), CrossCurrencyTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		ToAmount:      92,
+		ExchangeRate:  "0.92",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, int64(100), result.Transfer.Amount)
+	require.Equal(t, int64(92), result.Transfer.ToAmount.Int64)
+	require.Equal(t, "0.92", result.Transfer.ExchangeRate.String)
+
+	require.Equal(t, int64(-100), result.FromEntry.Amount)
+	require.Equal(t, int64(92), result.ToEntry.Amount)
+	for _, entry := range []Entry{result.FromEntry, result.ToEntry} {
+		require.Equal(t, result.Transfer.ID, entry.TransferID.Int64)
+		require.Equal(t, int64(100), entry.FromAmount.Int64)
+		require.Equal(t, int64(92), entry.ToAmount.Int64)
+		require.Equal(t, "0.92", entry.ExchangeRate.String)
+	}
+
+	require.Zero(t, result.FromAccount.Balance)
+	require.Equal(t, int64(92), result.ToAccount.Balance)
+
+	// The transfer is booked with four entries, so the reconciliation doesn't flag it
+	rows, err := testQueries.ListUnbalancedTransfers(context.Background())
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NotEqual(t, result.Transfer.ID, row.ID)
+	}
+}
+
+func TestCrossCurrencyTransferTxInsufficientFunds(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD, 10)
+	account2 := createAccountWithCurrency(t, util.EUR, 0)
+
+	_, err := store.CrossCurrencyTransferTx(context.Background(), CrossCurrencyTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        11,
+		ToAmount:      10,
+		ExchangeRate:  "0.92",
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCrossCurrencyTransferTxSameCurrency(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD, 10)
+	account2 := createAccountWithCurrency(t, util.USD, 0)
+
+	_, err := store.CrossCurrencyTransferTx(context.Background(), CrossCurrencyTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+		ToAmount:      10,
+		ExchangeRate:  "1",
+	})
+	require.ErrorIs(t, err, ErrSameCurrency)
+}
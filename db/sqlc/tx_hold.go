@@ -0,0 +1,241 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Statuses of `holds`, only active holds reserve funds
+const (
+	HoldActive   = "active"
+	HoldCaptured = "captured"
+	HoldReleased = "released"
+	HoldExpired  = "expired"
+)
+
+var (
+	ErrHoldNotActive      = errors.New("hold is not active")
+	ErrHoldExpired        = errors.New("hold has expired")
+	ErrCaptureExceedsHold = errors.New("capture amount exceeds the held amount")
+)
+
+type PlaceHoldTxParams struct {
+	AccountID   int64     `json:"account_id"`
+	ToAccountID int64     `json:"to_account_id"`
+	Amount      int64     `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type PlaceHoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// Reserves the amount and the fee of its capture on the account until the hold is captured, released or expires.
+// Fails with `ErrInsufficientFunds` if the available balance would go below the overdraft limit.
+func (store *SQLStore) PlaceHoldTx(ctx context.Context, arg PlaceHoldTxParams) (PlaceHoldTxResult, error) {
+	var result PlaceHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		fee, err := transferFee(ctx, q, account, arg.Amount)
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.CreateHold(ctx, CreateHoldParams{
+			AccountID:   arg.AccountID,
+			ToAccountID: arg.ToAccountID,
+			Amount:      arg.Amount,
+			Fee:         fee,
+			ExpiresAt:   arg.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     arg.AccountID,
+			Amount: arg.Amount + fee,
+		})
+		if err != nil {
+			return err
+		}
+
+		return checkAvailableBalance(result.Account)
+	})
+
+	return result, err
+}
+
+type CaptureHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+	// Zero captures the whole hold, the rest of a partial capture is released
+	Amount int64 `json:"amount"`
+}
+
+type CaptureHoldTxResult struct {
+	Hold     Hold             `json:"hold"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// Settles an active hold with a transfer to its to account and releases the held amount and fee.
+// The transfer is charged the fee of the captured amount, which the released fee covers unless the fee rule changed.
+func (store *SQLStore) CaptureHoldTx(ctx context.Context, arg CaptureHoldTxParams) (CaptureHoldTxResult, error) {
+	var result CaptureHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := getActiveHoldForUpdate(ctx, q, arg.HoldID)
+		if err != nil {
+			return err
+		}
+		if !hold.ExpiresAt.After(time.Now()) {
+			return fmt.Errorf("%w: hold [%d] expired at %s", ErrHoldExpired, hold.ID, hold.ExpiresAt.Format(time.RFC3339))
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = hold.Amount
+		}
+		if amount > hold.Amount {
+			return fmt.Errorf("%w: %d > %d", ErrCaptureExceedsHold, amount, hold.Amount)
+		}
+
+		// Both accounts are locked in id order like `TransferTx` does, before the held amount is released
+		if hold.AccountID < hold.ToAccountID {
+			_, _, err = lockAccounts(ctx, q, hold.AccountID, hold.ToAccountID)
+		} else {
+			_, _, err = lockAccounts(ctx, q, hold.ToAccountID, hold.AccountID)
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     hold.AccountID,
+			Amount: -(hold.Amount + hold.Fee),
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = store.transfer(ctx, q, TransferTxParams{
+			FromAccountID: hold.AccountID,
+			ToAccountID:   hold.ToAccountID,
+			Amount:        amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+			ID:         hold.ID,
+			Status:     HoldCaptured,
+			TransferID: sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type ReleaseHoldTxParams struct {
+	HoldID int64 `json:"hold_id"`
+}
+
+type ReleaseHoldTxResult struct {
+	Hold    Hold    `json:"hold"`
+	Account Account `json:"account"`
+}
+
+// Cancels an active hold, the held amount and fee are available again
+func (store *SQLStore) ReleaseHoldTx(ctx context.Context, arg ReleaseHoldTxParams) (ReleaseHoldTxResult, error) {
+	var result ReleaseHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := getActiveHoldForUpdate(ctx, q, arg.HoldID)
+		if err != nil {
+			return err
+		}
+
+		result.Account, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     hold.AccountID,
+			Amount: -(hold.Amount + hold.Fee),
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.UpdateHoldStatus(ctx, UpdateHoldStatusParams{
+			ID:     hold.ID,
+			Status: HoldReleased,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// Expires a batch of lapsed holds and releases their amounts and fees, returns the number of accounts updated.
+// Holds are locked before accounts like in `CaptureHoldTx`, and the accounts are updated one at a time
+// in id order like `TransferTx` locks them, so expiring holds can't deadlock with transfers.
+func (store *SQLStore) ExpireHoldsTx(ctx context.Context, limit int32) (int64, error) {
+	var accountIDs []int64
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		holds, err := q.ListLapsedHoldsForUpdate(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		holdIDs := make([]int64, len(holds))
+		released := make(map[int64]int64)
+		for i, hold := range holds {
+			holdIDs[i] = hold.ID
+			if _, ok := released[hold.AccountID]; !ok {
+				accountIDs = append(accountIDs, hold.AccountID)
+			}
+			released[hold.AccountID] += hold.Amount + hold.Fee
+		}
+		slices.Sort(accountIDs)
+
+		for _, accountID := range accountIDs {
+			_, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+				ID:     accountID,
+				Amount: -released[accountID],
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return q.ExpireHolds(ctx, holdIDs)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(accountIDs)), nil
+}
+
+// Locks the hold, so it is captured, released or expired only once
+func getActiveHoldForUpdate(ctx context.Context, q *Queries, holdID int64) (Hold, error) {
+	hold, err := q.GetHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return hold, err
+	}
+
+	if hold.Status != HoldActive {
+		return hold, fmt.Errorf("%w: hold [%d] is %s", ErrHoldNotActive, hold.ID, hold.Status)
+	}
+
+	return hold, nil
+}
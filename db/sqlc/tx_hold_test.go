@@ -0,0 +1,295 @@
+package db
+
+import (
+	"context"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Places a hold of `amount` from a new account funded with `balance` to a new account of the same currency
+func placeRandomHold(t *testing.T, balance int64, amount int64, expiresAt time.Time) PlaceHoldTxResult {
+	store := NewStore(testDB)
+
+	account := fundAccount(t, createRandomAccount(t), balance)
+	toAccount, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+
+	result, err := store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID:   account.ID,
+		ToAccountID: toAccount.ID,
+		Amount:      amount,
+		ExpiresAt:   expiresAt,
+	})
+	require.NoError(t, err)
+
+	return result
+}
+
+func TestPlaceHoldTx(t *testing.T) {
+	requireDB(t)
+
+	result := placeRandomHold(t, 10, 4, time.Now().Add(time.Hour))
+
+	require.Equal(t, HoldActive, result.Hold.Status)
+	require.Equal(t, int64(4), result.Hold.Amount)
+	require.False(t, result.Hold.TransferID.Valid)
+
+	require.Equal(t, int64(10), result.Account.Balance)
+	require.Equal(t, int64(4), result.Account.HeldAmount)
+}
+
+func TestPlaceHoldTxInsufficientFunds(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	result := placeRandomHold(t, 10, 6, time.Now().Add(time.Hour))
+
+	// Only 4 are still available
+	_, err := store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID:   result.Account.ID,
+		ToAccountID: result.Hold.ToAccountID,
+		Amount:      5,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestTransferTxAvailableBalance(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	result := placeRandomHold(t, 10, 6, time.Now().Add(time.Hour))
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: result.Account.ID,
+		ToAccountID:   result.Hold.ToAccountID,
+		Amount:        5,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	_, err = store.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID: result.Account.ID,
+		Amount:    5,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	transferResult, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: result.Account.ID,
+		ToAccountID:   result.Hold.ToAccountID,
+		Amount:        4,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(6), transferResult.FromAccount.Balance)
+	require.Equal(t, int64(6), transferResult.FromAccount.HeldAmount)
+}
+
+func TestCaptureHoldTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	hold := placeRandomHold(t, 10, 6, time.Now().Add(time.Hour)).Hold
+
+	// A partial capture releases the rest of the hold
+	result, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Amount: 5,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, HoldCaptured, result.Hold.Status)
+	require.Equal(t, result.Transfer.Transfer.ID, result.Hold.TransferID.Int64)
+	require.Equal(t, int64(5), result.Transfer.Transfer.Amount)
+
+	require.Equal(t, int64(5), result.Transfer.FromAccount.Balance)
+	require.Zero(t, result.Transfer.FromAccount.HeldAmount)
+	require.Equal(t, int64(5), result.Transfer.ToAccount.Balance)
+
+	// A hold is settled only once
+	_, err = store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+
+	_, err = store.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+func TestCaptureHoldTxExceedsHold(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	hold := placeRandomHold(t, 10, 6, time.Now().Add(time.Hour)).Hold
+
+	_, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{
+		HoldID: hold.ID,
+		Amount: 7,
+	})
+	require.ErrorIs(t, err, ErrCaptureExceedsHold)
+
+	// The hold is untouched
+	hold, err = testQueries.GetHold(context.Background(), hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldActive, hold.Status)
+}
+
+func TestCaptureHoldTxExpired(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	hold := placeRandomHold(t, 10, 6, time.Now().Add(-time.Second)).Hold
+
+	_, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: hold.ID})
+	require.ErrorIs(t, err, ErrHoldExpired)
+}
+
+func TestReleaseHoldTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	hold := placeRandomHold(t, 10, 6, time.Now().Add(time.Hour)).Hold
+
+	result, err := store.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{HoldID: hold.ID})
+	require.NoError(t, err)
+
+	require.Equal(t, HoldReleased, result.Hold.Status)
+	require.Equal(t, int64(10), result.Account.Balance)
+	require.Zero(t, result.Account.HeldAmount)
+}
+
+func TestExpireHoldsTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	result := placeRandomHold(t, 10, 6, time.Now().Add(-time.Second))
+
+	// Other tests may have left lapsed holds behind
+	for {
+		n, err := store.ExpireHoldsTx(context.Background(), 100)
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+	}
+
+	hold, err := testQueries.GetHold(context.Background(), result.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, HoldExpired, hold.Status)
+
+	account, err := testQueries.GetAccount(context.Background(), result.Account.ID)
+	require.NoError(t, err)
+	require.Zero(t, account.HeldAmount)
+}
+
+func TestExpireHoldsTxConcurrentTransfers(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	hold1 := placeRandomHold(t, 100, 10, time.Now().Add(-time.Second))
+	hold2 := placeRandomHold(t, 100, 10, time.Now().Add(-time.Second))
+	account1 := hold1.Account
+	account2 := hold2.Account
+
+	n := 10
+	errs := make(chan error)
+
+	// Transfers lock both accounts in id order while holds on both accounts expire
+	for i := 0; i < n; i++ {
+		fromAccountID := account1.ID
+		toAccountID := account2.ID
+		if i%2 == 1 {
+			fromAccountID = account2.ID
+			toAccountID = account1.ID
+		}
+
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: fromAccountID,
+				ToAccountID:   toAccountID,
+				Amount:        1,
+			})
+			errs <- err
+		}()
+	}
+	go func() {
+		_, err := store.ExpireHoldsTx(context.Background(), 100)
+		errs <- err
+	}()
+
+	for i := 0; i < n+1; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	// Other tests may have left lapsed holds behind
+	for {
+		n, err := store.ExpireHoldsTx(context.Background(), 100)
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+	}
+
+	for _, account := range []Account{account1, account2} {
+		account, err := testQueries.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(100), account.Balance)
+		require.Zero(t, account.HeldAmount)
+	}
+}
+
+func TestHoldTxReservesFee(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	newFeeRule(t, CreateFeeRuleParams{FlatFee: 2})
+
+	account := createBusinessAccount(t, util.USD, 10)
+	toAccount, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+
+	// 9 plus its fee of 2 is more than the balance
+	_, err = store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID:   account.ID,
+		ToAccountID: toAccount.ID,
+		Amount:      9,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	placed, err := store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID:   account.ID,
+		ToAccountID: toAccount.ID,
+		Amount:      8,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), placed.Hold.Fee)
+	require.Equal(t, int64(10), placed.Account.HeldAmount)
+
+	// The capture can pay the fee it reserved
+	captured, err := store.CaptureHoldTx(context.Background(), CaptureHoldTxParams{HoldID: placed.Hold.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), captured.Transfer.Transfer.Fee)
+	require.Zero(t, captured.Transfer.FromAccount.Balance)
+	require.Zero(t, captured.Transfer.FromAccount.HeldAmount)
+
+	// A release frees the fee with the amount
+	account = fundAccount(t, account, 10)
+	placed, err = store.PlaceHoldTx(context.Background(), PlaceHoldTxParams{
+		AccountID:   account.ID,
+		ToAccountID: toAccount.ID,
+		Amount:      8,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	released, err := store.ReleaseHoldTx(context.Background(), ReleaseHoldTxParams{HoldID: placed.Hold.ID})
+	require.NoError(t, err)
+	require.Zero(t, released.Account.HeldAmount)
+}
@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrTransferNotPending = errors.New("transfer is not pending")
+	ErrMissingConversion  = errors.New("a cross-currency transfer needs a converted amount")
+)
+
+type CompleteTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Only set when the accounts have different currencies, like `CrossCurrencyTransferTxParams`
+	ToAmount     int64  `json:"to_amount"`
+	ExchangeRate string `json:"exchange_rate"`
+}
+
+// Moves the money of a pending transfer like `TransferTx` or `CrossCurrencyTransferTx` would.
+// If the from account can't cover it the transfer is marked failed and `ErrInsufficientFunds` is returned.
+// A transfer over a limit stays pending, so it can be completed once the limit allows it.
+func (store *SQLStore) CompleteTransferTx(ctx context.Context, arg CompleteTransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+	var bookErr error
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		transfer, err := getPendingTransferForUpdate(ctx, q, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		// The accounts are locked in id order like in `TransferTx`
+		var fromAccount, toAccount Account
+		if transfer.FromAccountID < transfer.ToAccountID {
+			fromAccount, toAccount, err = lockAccounts(ctx, q, transfer.FromAccountID, transfer.ToAccountID)
+		} else {
+			toAccount, fromAccount, err = lockAccounts(ctx, q, transfer.ToAccountID, transfer.FromAccountID)
+		}
+		if err != nil {
+			return err
+		}
+
+		updateArg := UpdateTransferStatusParams{
+			ID:     transfer.ID,
+			Status: TransferStatusCompleted,
+		}
+
+		crossCurrency := fromAccount.Currency != toAccount.Currency
+		switch {
+		case crossCurrency && arg.ExchangeRate == "":
+			return ErrMissingConversion
+		case !crossCurrency && arg.ExchangeRate != "":
+			return ErrSameCurrency
+		case crossCurrency:
+			updateArg.ToAmount = sql.NullInt64{Int64: arg.ToAmount, Valid: true}
+			updateArg.ExchangeRate = sql.NullString{String: arg.ExchangeRate, Valid: true}
+		}
+
+		transfer, err = q.UpdateTransferStatus(ctx, updateArg)
+		if err != nil {
+			return err
+		}
+
+		// The money moves in a savepoint, so a transfer that can't be covered is still marked failed
+		bookErr = withSavepoint(ctx, q, "complete_transfer", func() error {
+			var err error
+			if crossCurrency {
+				result, err = bookConversionEntries(ctx, q, transfer, fromAccount.Currency, toAccount.Currency)
+			} else {
+				result, err = bookEntries(ctx, q, transfer)
+			}
+			if err != nil {
+				return err
+			}
+			return store.checkTransferLimits(ctx, q, result.FromAccount, transfer.Amount)
+		})
+		if !errors.Is(bookErr, ErrInsufficientFunds) {
+			return bookErr
+		}
+
+		result = TransferTxResult{FromAccount: fromAccount, ToAccount: toAccount}
+		result.Transfer, err = q.UpdateTransferStatus(ctx, UpdateTransferStatusParams{
+			ID:     transfer.ID,
+			Status: TransferStatusFailed,
+		})
+		return err
+	})
+	if err != nil {
+		return result, err
+	}
+
+	return result, bookErr
+}
+
+type CancelTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+}
+
+// Marks a pending transfer cancelled, no money has moved
+func (store *SQLStore) CancelTransferTx(ctx context.Context, arg CancelTransferTxParams) (Transfer, error) {
+	var transfer Transfer
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		transfer, err = getPendingTransferForUpdate(ctx, q, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		transfer, err = q.UpdateTransferStatus(ctx, UpdateTransferStatusParams{
+			ID:     transfer.ID,
+			Status: TransferStatusCancelled,
+		})
+		return err
+	})
+
+	return transfer, err
+}
+
+// Locks the transfer, so it is completed or cancelled only once
+func getPendingTransferForUpdate(ctx context.Context, q *Queries, transferID int64) (Transfer, error) {
+	transfer, err := q.GetTransferForUpdate(ctx, transferID)
+	if err != nil {
+		return transfer, err
+	}
+
+	if transfer.Status != TransferStatusPending {
+		return transfer, fmt.Errorf("%w: transfer [%d] is %s", ErrTransferNotPending, transfer.ID, transfer.Status)
+	}
+
+	return transfer, nil
+}
@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"simple-bank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newPendingTransfer(t *testing.T, fromAccount Account, toAccount Account, amount int64) Transfer {
+	transfer, err := testQueries.CreatePendingTransfer(context.Background(), CreatePendingTransferParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusPending, transfer.Status)
+
+	// No money moves until the transfer is completed
+	account, err := testQueries.GetAccount(context.Background(), fromAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, fromAccount.Balance, account.Balance)
+
+	return transfer
+}
+
+func TestCompleteTransferTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD, 10)
+	account2 := createAccountWithCurrency(t, util.USD, 0)
+	transfer := newPendingTransfer(t, account1, account2, 10)
+
+	result, err := store.CompleteTransferTx(context.Background(), CompleteTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, TransferStatusCompleted, result.Transfer.Status)
+	require.Equal(t, transfer.ID, result.FromEntry.TransferID.Int64)
+	require.Equal(t, int64(-10), result.FromEntry.Amount)
+	require.Equal(t, int64(10), result.ToEntry.Amount)
+	require.Zero(t, result.FromAccount.Balance)
+	require.Equal(t, int64(10), result.ToAccount.Balance)
+
+	_, err = store.CompleteTransferTx(context.Background(), CompleteTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrTransferNotPending)
+
+	_, err = store.CancelTransferTx(context.Background(), CancelTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrTransferNotPending)
+}
+
+func TestCompleteTransferTxCrossCurrency(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD, 100)
+	account2 := createAccountWithCurrency(t, util.EUR, 0)
+	transfer := newPendingTransfer(t, account1, account2, 100)
+
+	_, err := store.CompleteTransferTx(context.Background(), CompleteTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrMissingConversion)
+
+	result, err := store.CompleteTransferTx(context.Background(), CompleteTransferTxParams{
+		TransferID:   transfer.ID,
+		ToAmount:     92,
+		ExchangeRate: "0.92",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, TransferStatusCompleted, result.Transfer.Status)
+	require.Equal(t, int64(92), result.Transfer.ToAmount.Int64)
+	require.Equal(t, "0.92", result.Transfer.ExchangeRate.String)
+	require.Zero(t, result.FromAccount.Balance)
+	require.Equal(t, int64(92), result.ToAccount.Balance)
+}
+
+func TestCompleteTransferTxInsufficientFunds(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD, 9)
+	account2 := createAccountWithCurrency(t, util.USD, 0)
+	transfer := newPendingTransfer(t, account1, account2, 10)
+
+	result, err := store.CompleteTransferTx(context.Background(), CompleteTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+	require.Equal(t, TransferStatusFailed, result.Transfer.Status)
+
+	// The failed status is kept, the entries and balance updates are not
+	transfer, err = testQueries.GetTransfer(context.Background(), transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusFailed, transfer.Status)
+
+	account1, err = testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(9), account1.Balance)
+
+	rows, err := testQueries.ListUnbalancedTransfers(context.Background())
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NotEqual(t, transfer.ID, row.ID)
+	}
+}
+
+func TestCancelTransferTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD, 10)
+	account2 := createAccountWithCurrency(t, util.USD, 0)
+	transfer := newPendingTransfer(t, account1, account2, 10)
+
+	transfer, err := store.CancelTransferTx(context.Background(), CancelTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusCancelled, transfer.Status)
+
+	_, err = store.CompleteTransferTx(context.Background(), CompleteTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrTransferNotPending)
+
+	// Only completed transfers can be reversed
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrTransferNotCompleted)
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Returned by `RenewSessionTx` when the old session was blocked, eg: the user logged out
+var ErrSessionBlocked = errors.New("session is blocked")
+
+// Returned by `RenewSessionTx` when the old session was already replaced,
+// which means its refresh token has been used before and may be stolen
+var ErrRefreshTokenReused = errors.New("refresh token is reused")
+
+type RenewSessionTxParams struct {
+	OldSessionID uuid.UUID
+	// FamilyID of the new session must be the family of the old session
+	NewSession CreateSessionParams
+}
+
+// Rotates the refresh token of a session.
+// The new session is created and the old one is marked as replaced in the same transaction,
+// so a refresh token can only be used once.
+func (store *SQLStore) RenewSessionTx(ctx context.Context, arg RenewSessionTxParams) (Session, error) {
+	var session Session
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		session, err = q.CreateSession(ctx, arg.NewSession)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.ReplaceSession(ctx, ReplaceSessionParams{
+			ID:         arg.OldSessionID,
+			ReplacedBy: uuid.NullUUID{UUID: session.ID, Valid: true},
+		})
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		oldSession, err := q.GetSession(ctx, arg.OldSessionID)
+		if err != nil {
+			return err
+		}
+		if oldSession.ReplacedBy.Valid {
+			return ErrRefreshTokenReused
+		}
+		return ErrSessionBlocked
+	})
+
+	return session, err
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrReversalOfReversal    = errors.New("a reversal can't be reversed")
+	ErrTransferNotCompleted  = errors.New("only completed transfers can be reversed")
+	ErrAlreadyReversed       = errors.New("transfer is already fully reversed")
+	ErrRefundExceedsTransfer = errors.New("refund exceeds the amount left to reverse")
+	ErrRefundTooSmall        = errors.New("refund is too small to convert back")
+)
+
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// In the currency of the from account of the transfer, zero refunds whatever is left to reverse
+	Amount int64 `json:"amount"`
+}
+
+type ReverseTransferTxResult struct {
+	// The transfer being reversed, its status is reversed once it is refunded in full
+	Transfer Transfer `json:"transfer"`
+	// Moves the refund from the to account of the transfer back to its from account
+	Reversal TransferTxResult `json:"reversal"`
+	// What can still be refunded after this reversal
+	RemainingAmount int64 `json:"remaining_amount"`
+}
+
+// Refunds all or part of a transfer with a compensating transfer linked to it by `reversed_transfer_id`.
+// The refunds of a transfer never add up to more than its amount.
+// A cross-currency transfer is reversed at its original rate, so a full reversal gives back exactly its to amount.
+// The fee of the transfer is not refunded, and reversals are charged no fee.
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		// Locking the transfer serializes its reversals
+		result.Transfer, err = q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+		original := result.Transfer
+
+		if original.ReversedTransferID.Valid {
+			return ErrReversalOfReversal
+		}
+		if original.Status == TransferStatusReversed {
+			return fmt.Errorf("%w: transfer [%d]", ErrAlreadyReversed, original.ID)
+		}
+		if original.Status != TransferStatusCompleted {
+			return fmt.Errorf("%w: transfer [%d] is %s", ErrTransferNotCompleted, original.ID, original.Status)
+		}
+
+		reversedAmount, err := q.GetReversedAmount(ctx, sql.NullInt64{Int64: original.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		remainingAmount := original.Amount - reversedAmount
+		if remainingAmount <= 0 {
+			return fmt.Errorf("%w: transfer [%d]", ErrAlreadyReversed, original.ID)
+		}
+
+		amount := arg.Amount
+		if amount == 0 {
+			amount = remainingAmount
+		}
+		if amount > remainingAmount {
+			return fmt.Errorf("%w: %d > %d", ErrRefundExceedsTransfer, amount, remainingAmount)
+		}
+
+		// The accounts are locked in id order like in `TransferTx`
+		var fromAccount, toAccount Account
+		if original.FromAccountID < original.ToAccountID {
+			fromAccount, toAccount, err = lockAccounts(ctx, q, original.FromAccountID, original.ToAccountID)
+		} else {
+			toAccount, fromAccount, err = lockAccounts(ctx, q, original.ToAccountID, original.FromAccountID)
+		}
+		if err != nil {
+			return err
+		}
+
+		reversalArg := CreateTransferParams{
+			FromAccountID:      original.ToAccountID,
+			ToAccountID:        original.FromAccountID,
+			ReversedTransferID: sql.NullInt64{Int64: original.ID, Valid: true},
+		}
+
+		if !original.ExchangeRate.Valid {
+			reversalArg.Amount = amount
+			result.Reversal, err = bookTransfer(ctx, q, reversalArg)
+		} else {
+			// The to account gives back its share of the to amount. Shares are taken of the running total,
+			// so rounding never leaves a unit behind once the whole transfer is refunded.
+			toAmount := share(original.ToAmount.Int64, reversedAmount+amount, original.Amount) -
+				share(original.ToAmount.Int64, reversedAmount, original.Amount)
+			if toAmount <= 0 {
+				return fmt.Errorf("%w: %d", ErrRefundTooSmall, amount)
+			}
+
+			var exchangeRate string
+			exchangeRate, err = inverseRate(original.ExchangeRate.String)
+			if err != nil {
+				return err
+			}
+
+			reversalArg.Amount = toAmount
+			reversalArg.ToAmount = sql.NullInt64{Int64: amount, Valid: true}
+			reversalArg.ExchangeRate = sql.NullString{String: exchangeRate, Valid: true}
+			result.Reversal, err = bookConversion(ctx, q, reversalArg, toAccount.Currency, fromAccount.Currency)
+		}
+		if err != nil {
+			return err
+		}
+
+		result.RemainingAmount = remainingAmount - amount
+		if result.RemainingAmount == 0 {
+			result.Transfer, err = q.UpdateTransferStatus(ctx, UpdateTransferStatusParams{
+				ID:     original.ID,
+				Status: TransferStatusReversed,
+			})
+		}
+		return err
+	})
+
+	return result, err
+}
+
+// Returns `total` * `part` / `whole` rounded down, without overflowing
+func share(total int64, part int64, whole int64) int64 {
+	value := new(big.Int).Mul(big.NewInt(total), big.NewInt(part))
+	return value.Quo(value, big.NewInt(whole)).Int64()
+}
+
+// Returns 1 / `rate` as a decimal number
+func inverseRate(rate string) (string, error) {
+	value, ok := new(big.Rat).SetString(rate)
+	if !ok || value.Sign() <= 0 {
+		return "", fmt.Errorf("invalid exchange rate %q", rate)
+	}
+
+	inverse := value.Inv(value).FloatString(12)
+	return strings.TrimRight(strings.TrimRight(inverse, "0"), "."), nil
+}
@@ -0,0 +1,173 @@
+package db
+
+import (
+	"context"
+	"simple-bank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseTransferTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD, 10)
+	account2 := createAccountWithCurrency(t, util.USD, 0)
+
+	original, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// A partial refund
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     4,
+	})
+	require.NoError(t, err)
+
+	reversal := result.Reversal
+	require.Equal(t, original.Transfer.ID, reversal.Transfer.ReversedTransferID.Int64)
+	require.Equal(t, account2.ID, reversal.Transfer.FromAccountID)
+	require.Equal(t, account1.ID, reversal.Transfer.ToAccountID)
+	require.Equal(t, int64(4), reversal.Transfer.Amount)
+	require.Equal(t, int64(-4), reversal.FromEntry.Amount)
+	require.Equal(t, int64(4), reversal.ToEntry.Amount)
+	require.Equal(t, int64(6), reversal.FromAccount.Balance)
+	require.Equal(t, int64(4), reversal.ToAccount.Balance)
+	require.Equal(t, int64(6), result.RemainingAmount)
+	require.Equal(t, TransferStatusCompleted, result.Transfer.Status)
+
+	// Refunds never add up to more than the transfer
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     7,
+	})
+	require.ErrorIs(t, err, ErrRefundExceedsTransfer)
+
+	// The rest of the transfer
+	result, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(6), result.Reversal.Transfer.Amount)
+	require.Zero(t, result.Reversal.FromAccount.Balance)
+	require.Equal(t, int64(10), result.Reversal.ToAccount.Balance)
+	require.Zero(t, result.RemainingAmount)
+	require.Equal(t, TransferStatusReversed, result.Transfer.Status)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrAlreadyReversed)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Reversal.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrReversalOfReversal)
+}
+
+func TestReverseTransferTxInsufficientFunds(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD, 10)
+	account2 := createAccountWithCurrency(t, util.USD, 0)
+	account3 := createAccountWithCurrency(t, util.USD, 0)
+
+	original, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// The money was spent already
+	_, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account3.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestReverseTransferTxCrossCurrency(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, util.USD, 100)
+	account2 := createAccountWithCurrency(t, util.EUR, 0)
+
+	original, err := store.CrossCurrencyTransferTx(context.Background(), CrossCurrencyTransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		ToAmount:      92,
+		ExchangeRate:  "0.92",
+	})
+	require.NoError(t, err)
+
+	// 92 * 33 / 100 = 30.36
+	result, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+		Amount:     33,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(30), result.Reversal.Transfer.Amount)
+	require.Equal(t, int64(33), result.Reversal.Transfer.ToAmount.Int64)
+	require.NotEmpty(t, result.Reversal.Transfer.ExchangeRate.String)
+	require.Equal(t, int64(33), result.Reversal.ToAccount.Balance)
+
+	// The rest gives back exactly what is left of the to amount
+	result, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: original.Transfer.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(62), result.Reversal.Transfer.Amount)
+	require.Equal(t, int64(67), result.Reversal.Transfer.ToAmount.Int64)
+	require.Zero(t, result.Reversal.FromAccount.Balance)
+	require.Equal(t, int64(100), result.Reversal.ToAccount.Balance)
+
+	// The reversal is booked with four entries, so the reconciliation doesn't flag it
+	rows, err := testQueries.ListUnbalancedTransfers(context.Background())
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NotEqual(t, result.Reversal.Transfer.ID, row.ID)
+	}
+}
+
+func TestInverseRate(t *testing.T) {
+	rate, err := inverseRate("0.8")
+	require.NoError(t, err)
+	require.Equal(t, "1.25", rate)
+
+	rate, err = inverseRate("3")
+	require.NoError(t, err)
+	require.Equal(t, "0.333333333333", rate)
+
+	rate, err = inverseRate("0.5")
+	require.NoError(t, err)
+	require.Equal(t, "2", rate)
+
+	_, err = inverseRate("0")
+	require.Error(t, err)
+
+	_, err = inverseRate("abc")
+	require.Error(t, err)
+}
+
+func TestShare(t *testing.T) {
+	require.Equal(t, int64(30), share(92, 33, 100))
+	require.Equal(t, int64(92), share(92, 100, 100))
+	require.Zero(t, share(92, 1, 100))
+	// No overflow in the intermediate product
+	require.Equal(t, int64(1<<61), share(1<<62, 1<<62, 1<<63-1))
+}
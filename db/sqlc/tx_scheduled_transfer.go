@@ -0,0 +1,179 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Statuses of `scheduled_transfers`, only active ones are executed
+const (
+	ScheduledTransferActive    = "active"
+	ScheduledTransferPaused    = "paused"
+	ScheduledTransferCompleted = "completed"
+	ScheduledTransferCancelled = "cancelled"
+)
+
+// Statuses of `scheduled_transfer_runs`
+const (
+	ScheduledTransferRunCompleted = "completed"
+	ScheduledTransferRunFailed    = "failed"
+)
+
+type ExecuteScheduledTransferTxParams struct {
+	// Returns the occurrence after the one being executed, false means there is none and the scheduled transfer is completed
+	NextRunAt func(scheduledTransfer ScheduledTransfer) (time.Time, bool, error)
+}
+
+type ExecuteScheduledTransferTxResult struct {
+	ScheduledTransfer ScheduledTransfer
+	Run               ScheduledTransferRun
+	// Empty if the run failed
+	Transfer TransferTxResult
+}
+
+// Executes the most overdue occurrence of any active scheduled transfer, or returns `sql.ErrNoRows` if none is due.
+// The run is recorded and the scheduled transfer advanced in the same transaction as the transfer,
+// so an occurrence is executed exactly once even with several schedulers.
+// A transfer that is refused, for insufficient funds or over a limit, is recorded as a failed run and the occurrence is skipped.
+// Any other failure rolls the transaction back, the occurrence is then skipped in a separate transaction,
+// so the scheduled transfer isn't claimed again on every poll ahead of the others that are due.
+func (store *SQLStore) ExecuteScheduledTransferTx(ctx context.Context, arg ExecuteScheduledTransferTxParams) (ExecuteScheduledTransferTxResult, error) {
+	var result ExecuteScheduledTransferTxResult
+	var claimed ScheduledTransfer
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		scheduledTransfer, err := q.ClaimDueScheduledTransfer(ctx)
+		if err != nil {
+			return err
+		}
+		claimed = scheduledTransfer
+
+		runArg := CreateScheduledTransferRunParams{
+			ScheduledTransferID: scheduledTransfer.ID,
+			ScheduledFor:        scheduledTransfer.NextRunAt,
+			Status:              ScheduledTransferRunCompleted,
+		}
+
+		// The transfer runs in a savepoint, so a refused transfer is undone without losing the run record
+		err = withSavepoint(ctx, q, "scheduled_transfer", func() error {
+			var err error
+			result.Transfer, err = store.transfer(ctx, q, TransferTxParams{
+				FromAccountID: scheduledTransfer.FromAccountID,
+				ToAccountID:   scheduledTransfer.ToAccountID,
+				Amount:        scheduledTransfer.Amount,
+			})
+			return err
+		})
+		switch {
+		case err == nil:
+			runArg.TransferID = sql.NullInt64{Int64: result.Transfer.Transfer.ID, Valid: true}
+		case errors.Is(err, ErrInsufficientFunds), errors.Is(err, ErrLimitExceeded):
+			result.Transfer = TransferTxResult{}
+			runArg.Status = ScheduledTransferRunFailed
+			runArg.Error = err.Error()
+		default:
+			return err
+		}
+
+		result.Run, err = q.CreateScheduledTransferRun(ctx, runArg)
+		if err != nil {
+			return err
+		}
+
+		nextRunAt, ok, err := arg.NextRunAt(scheduledTransfer)
+		if err != nil {
+			return err
+		}
+
+		updateArg := UpdateScheduledTransferParams{ID: scheduledTransfer.ID}
+		if ok {
+			updateArg.NextRunAt = sql.NullTime{Time: nextRunAt, Valid: true}
+		} else {
+			updateArg.Status = sql.NullString{String: ScheduledTransferCompleted, Valid: true}
+		}
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransfer(ctx, updateArg)
+		return err
+	})
+	if err != nil && claimed.ID != 0 {
+		return store.skipScheduledTransferRun(ctx, arg, claimed, err)
+	}
+
+	return result, err
+}
+
+// Records the occurrence of `claimed` that failed with `cause` as a failed run and moves on to the next one.
+// A scheduled transfer whose next occurrence can't be worked out is paused.
+// Fails with `cause` if the occurrence couldn't be skipped either, eg: the database is unavailable.
+func (store *SQLStore) skipScheduledTransferRun(
+	ctx context.Context,
+	arg ExecuteScheduledTransferTxParams,
+	claimed ScheduledTransfer,
+	cause error,
+) (ExecuteScheduledTransferTxResult, error) {
+	var result ExecuteScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		scheduledTransfer, err := q.GetScheduledTransferForUpdate(ctx, claimed.ID)
+		if err != nil {
+			return err
+		}
+
+		// Another scheduler or the owner already moved it on
+		if scheduledTransfer.Status != ScheduledTransferActive || !scheduledTransfer.NextRunAt.Equal(claimed.NextRunAt) {
+			return cause
+		}
+
+		result.Run, err = q.CreateScheduledTransferRun(ctx, CreateScheduledTransferRunParams{
+			ScheduledTransferID: scheduledTransfer.ID,
+			ScheduledFor:        scheduledTransfer.NextRunAt,
+			Status:              ScheduledTransferRunFailed,
+			Error:               cause.Error(),
+		})
+		if err != nil {
+			return err
+		}
+
+		updateArg := UpdateScheduledTransferParams{ID: scheduledTransfer.ID}
+		nextRunAt, ok, err := arg.NextRunAt(scheduledTransfer)
+		switch {
+		case err != nil:
+			updateArg.Status = sql.NullString{String: ScheduledTransferPaused, Valid: true}
+		case ok:
+			updateArg.NextRunAt = sql.NullTime{Time: nextRunAt, Valid: true}
+		default:
+			updateArg.Status = sql.NullString{String: ScheduledTransferCompleted, Valid: true}
+		}
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransfer(ctx, updateArg)
+		return err
+	})
+	if err != nil {
+		if err == cause {
+			return result, cause
+		}
+		return result, fmt.Errorf("%w, and the occurrence couldn't be skipped: %v", cause, err)
+	}
+
+	return result, nil
+}
+
+// Runs `fn` in a savepoint, so its writes can be undone without aborting the whole transaction
+func withSavepoint(ctx context.Context, q *Queries, name string, fn func() error) error {
+	if _, err := q.db.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		if _, rbErr := q.db.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return fmt.Errorf("savepoint err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	_, err := q.db.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
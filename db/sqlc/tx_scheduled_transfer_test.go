@@ -0,0 +1,184 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Creates a one-off scheduled transfer between two new accounts, overdue so it is claimed before any other
+func createDueScheduledTransfer(t *testing.T, fromBalance int64, amount int64) ScheduledTransfer {
+	fromAccount := fundAccount(t, createRandomAccount(t), fromBalance)
+
+	toAccount, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Currency: fromAccount.Currency,
+	})
+	require.NoError(t, err)
+
+	scheduledTransfer, err := testQueries.CreateScheduledTransfer(context.Background(), CreateScheduledTransferParams{
+		Owner:         fromAccount.Owner,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount,
+		Currency:      fromAccount.Currency,
+		NextRunAt:     time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferActive, scheduledTransfer.Status)
+
+	return scheduledTransfer
+}
+
+func noNextRun(ScheduledTransfer) (time.Time, bool, error) {
+	return time.Time{}, false, nil
+}
+
+func TestExecuteScheduledTransferTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	scheduledTransfer := createDueScheduledTransfer(t, 10, 10)
+
+	result, err := store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+		NextRunAt: noNextRun,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, scheduledTransfer.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, ScheduledTransferCompleted, result.ScheduledTransfer.Status)
+
+	require.Equal(t, ScheduledTransferRunCompleted, result.Run.Status)
+	require.True(t, scheduledTransfer.NextRunAt.Equal(result.Run.ScheduledFor))
+	require.Equal(t, result.Transfer.Transfer.ID, result.Run.TransferID.Int64)
+
+	require.Zero(t, result.Transfer.FromAccount.Balance)
+	require.Equal(t, int64(10), result.Transfer.ToAccount.Balance)
+
+	// A completed scheduled transfer is never claimed again
+	runs, err := testQueries.ListScheduledTransferRuns(context.Background(), ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Limit:               10,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+}
+
+func TestExecuteScheduledTransferTxInsufficientFunds(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	scheduledTransfer := createDueScheduledTransfer(t, 10, 11)
+	nextRunAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	result, err := store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+		NextRunAt: func(ScheduledTransfer) (time.Time, bool, error) {
+			return nextRunAt, true, nil
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, scheduledTransfer.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, ScheduledTransferActive, result.ScheduledTransfer.Status)
+	require.True(t, nextRunAt.Equal(result.ScheduledTransfer.NextRunAt))
+
+	require.Equal(t, ScheduledTransferRunFailed, result.Run.Status)
+	require.Contains(t, result.Run.Error, ErrInsufficientFunds.Error())
+	require.False(t, result.Run.TransferID.Valid)
+
+	// The refused transfer is rolled back
+	fromAccount, err := testQueries.GetAccount(context.Background(), scheduledTransfer.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), fromAccount.Balance)
+}
+
+func TestExecuteScheduledTransferTxUnexpectedError(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	scheduledTransfer := createDueScheduledTransfer(t, 10, 10)
+	errSchedule := errors.New("invalid schedule")
+
+	// The next occurrence can't be worked out, so the transfer is rolled back
+	result, err := store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+		NextRunAt: func(ScheduledTransfer) (time.Time, bool, error) {
+			return time.Time{}, false, errSchedule
+		},
+	})
+	require.NoError(t, err)
+
+	// The occurrence is recorded as failed and the scheduled transfer paused, so it isn't claimed again
+	require.Equal(t, scheduledTransfer.ID, result.ScheduledTransfer.ID)
+	require.Equal(t, ScheduledTransferPaused, result.ScheduledTransfer.Status)
+
+	require.Equal(t, ScheduledTransferRunFailed, result.Run.Status)
+	require.Equal(t, errSchedule.Error(), result.Run.Error)
+	require.True(t, scheduledTransfer.NextRunAt.Equal(result.Run.ScheduledFor))
+	require.False(t, result.Run.TransferID.Valid)
+
+	fromAccount, err := testQueries.GetAccount(context.Background(), scheduledTransfer.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), fromAccount.Balance)
+}
+
+func TestExecuteScheduledTransferTxConcurrent(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	scheduledTransfer := createDueScheduledTransfer(t, 10, 1)
+
+	n := 5
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.ExecuteScheduledTransferTx(context.Background(), ExecuteScheduledTransferTxParams{
+				NextRunAt: noNextRun,
+			})
+			if err == sql.ErrNoRows {
+				err = nil
+			}
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	// Each occurrence runs exactly once, whichever scheduler claims it
+	runs, err := testQueries.ListScheduledTransferRuns(context.Background(), ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Limit:               10,
+	})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+
+	fromAccount, err := testQueries.GetAccount(context.Background(), scheduledTransfer.FromAccountID)
+	require.NoError(t, err)
+	require.Equal(t, int64(9), fromAccount.Balance)
+}
+
+func TestUpdateScheduledTransferFinal(t *testing.T) {
+	requireDB(t)
+
+	scheduledTransfer := createDueScheduledTransfer(t, 10, 10)
+
+	cancelled, err := testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:     scheduledTransfer.ID,
+		Status: sql.NullString{String: ScheduledTransferCancelled, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferCancelled, cancelled.Status)
+
+	// A cancelled scheduled transfer can't be resumed by an update that read it before it was cancelled
+	_, err = testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:     scheduledTransfer.ID,
+		Status: sql.NullString{String: ScheduledTransferActive, Valid: true},
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+)
+
+type UpdateUserTxParams struct {
+	UpdateUserParams
+	// Runs inside the transaction after the user is updated, an error rolls back the update.
+	// Follow-up writes must go through `q` to be part of the transaction.
+	AfterUpdate func(q Querier, oldUser User, user User) error
+}
+
+type UpdateUserTxResult struct {
+	User User
+}
+
+// Updates a user and runs `AfterUpdate` with the user as it was before, in the same transaction.
+// Fails with `sql.ErrNoRows` if the user doesn't exist.
+func (store *SQLStore) UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error) {
+	var result UpdateUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// Locked, so a concurrent update can't change the email between the read and the update
+		oldUser, err := q.GetUserForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.UpdateUser(ctx, arg.UpdateUserParams)
+		if err != nil {
+			return err
+		}
+
+		if arg.AfterUpdate == nil {
+			return nil
+		}
+		return arg.AfterUpdate(q, oldUser, result.User)
+	})
+
+	return result, err
+}
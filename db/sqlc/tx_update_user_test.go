@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"simple-bank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateUserTx(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+	_, err := testQueries.UpdateUser(context.Background(), UpdateUserParams{
+		Username:        user.Username,
+		IsEmailVerified: sql.NullBool{Bool: true, Valid: true},
+	})
+	require.NoError(t, err)
+
+	newEmail := util.RandomEmail()
+	var hookOldUser, hookUser User
+	arg := UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+			Email:    sql.NullString{String: newEmail, Valid: true},
+		},
+		AfterUpdate: func(q Querier, oldUser User, user User) error {
+			hookOldUser, hookUser = oldUser, user
+			return nil
+		},
+	}
+
+	result, err := store.UpdateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, newEmail, result.User.Email)
+	require.False(t, result.User.IsEmailVerified)
+
+	// The hook sees the email before and after the change
+	require.Equal(t, user.Email, hookOldUser.Email)
+	require.True(t, hookOldUser.IsEmailVerified)
+	require.Equal(t, result.User, hookUser)
+}
+
+func TestUpdateUserTxRollback(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	user := createRandomUser(t)
+
+	errHook := errors.New("hook failed")
+	arg := UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+			Email:    sql.NullString{String: util.RandomEmail(), Valid: true},
+		},
+		AfterUpdate: func(q Querier, oldUser User, user User) error {
+			return errHook
+		},
+	}
+
+	_, err := store.UpdateUserTx(context.Background(), arg)
+	require.ErrorIs(t, err, errHook)
+
+	// The email change is rolled back with the failed hook
+	got, err := testQueries.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, user.Email, got.Email)
+}
+
+func TestUpdateUserTxNotFound(t *testing.T) {
+	requireDB(t)
+	store := NewStore(testDB)
+
+	_, err := store.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: util.RandomOwner(),
+			FullName: sql.NullString{String: util.RandomOwner(), Valid: true},
+		},
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
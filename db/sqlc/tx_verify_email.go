@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Returned by `VerifyEmailTx` when the code is wrong, already used or expired
+var ErrInvalidVerifyEmail = errors.New("invalid or expired verification code")
+
+type VerifyEmailTxParams struct {
+	EmailId    int64
+	SecretCode string
+}
+
+type VerifyEmailTxResult struct {
+	User        User
+	VerifyEmail VerifyEmail
+}
+
+// Marks the verification code as used and the email of its user as verified in one transaction
+func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error) {
+	var result VerifyEmailTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.VerifyEmail, err = q.UpdateVerifyEmail(ctx, UpdateVerifyEmailParams{
+			ID:         arg.EmailId,
+			SecretCode: arg.SecretCode,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrInvalidVerifyEmail
+			}
+			return err
+		}
+
+		// The code was sent to an address the user has changed since
+		user, err := q.GetUser(ctx, result.VerifyEmail.Username)
+		if err != nil {
+			return err
+		}
+		if user.Email != result.VerifyEmail.Email {
+			return ErrInvalidVerifyEmail
+		}
+
+		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+			Username:        result.VerifyEmail.Username,
+			IsEmailVerified: sql.NullBool{Bool: true, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"simple-bank/rbac"
 	"simple-bank/util"
 	"testing"
 	"time"
@@ -29,6 +30,8 @@ func createRandomUser(t *testing.T) User {
 	require.Equal(t, arg.HashedPassword, user.HashedPassword)
 	require.Equal(t, arg.FullName, user.FullName)
 	require.Equal(t, arg.Email, user.Email)
+	// Every new user is a depositor, bankers are promoted explicitly
+	require.Equal(t, rbac.DepositorRole, user.Role)
 
 	require.True(t, user.PasswordChangedAt.IsZero())
 	require.NotZero(t, user.CreatedAt)
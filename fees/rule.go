@@ -0,0 +1,63 @@
+package fees
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrFeeOverflows = errors.New("fee is too large")
+
+// Basis points in a whole, a rate of 100 bps is 1% of the amount
+const bpsPerWhole = 10_000
+
+// How a transfer fee is worked out, in the minor units of the currency of the from account.
+// Rules are stored in the `fee_rules` table, per currency and account type.
+type Rule struct {
+	// Charged on every transfer
+	Flat int64 `json:"flat_fee"`
+	// Hundredths of a percent of the amount, charged on top of `Flat`
+	RateBps int64 `json:"rate_bps"`
+	// The fee is raised to `Min` and capped at `Max`, 0 means no minimum or maximum
+	Min int64 `json:"min_fee"`
+	Max int64 `json:"max_fee"`
+}
+
+func (rule Rule) Validate() error {
+	if rule.Flat < 0 || rule.Min < 0 || rule.Max < 0 {
+		return fmt.Errorf("fees cannot be negative")
+	}
+	if rule.RateBps < 0 || rule.RateBps > bpsPerWhole {
+		return fmt.Errorf("rate must be between 0 and %d bps", bpsPerWhole)
+	}
+	if rule.Max != 0 && rule.Max < rule.Min {
+		return fmt.Errorf("maximum fee cannot be below the minimum fee")
+	}
+	return nil
+}
+
+// Returns the fee of a transfer of `amount`.
+// The percentage is rounded up, so a fee is never lost to rounding.
+func (rule Rule) Fee(amount int64) (int64, error) {
+	if err := rule.Validate(); err != nil {
+		return 0, err
+	}
+	if amount <= 0 {
+		return 0, fmt.Errorf("amount must be positive")
+	}
+
+	// Split so the multiplication can't overflow, the rate is at most one whole
+	percentage := amount/bpsPerWhole*rule.RateBps + (amount%bpsPerWhole*rule.RateBps+bpsPerWhole-1)/bpsPerWhole
+
+	if rule.Flat > math.MaxInt64-percentage {
+		return 0, ErrFeeOverflows
+	}
+	fee := rule.Flat + percentage
+
+	fee = max(fee, rule.Min)
+	if rule.Max > 0 {
+		fee = min(fee, rule.Max)
+	}
+
+	return fee, nil
+}
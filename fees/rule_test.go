@@ -0,0 +1,66 @@
+package fees
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFee(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		amount  int64
+		want    int64
+		wantErr error
+	}{
+		{"Free", Rule{}, 100, 0, nil},
+		{"Flat", Rule{Flat: 25}, 100, 25, nil},
+		{"Percentage", Rule{RateBps: 150}, 1000, 15, nil},
+		{"RoundsUp", Rule{RateBps: 150}, 1001, 16, nil},
+		{"FlatAndPercentage", Rule{Flat: 10, RateBps: 100}, 1000, 20, nil},
+		{"Minimum", Rule{RateBps: 100, Min: 50}, 1000, 50, nil},
+		{"Maximum", Rule{RateBps: 100, Max: 5}, 1000, 5, nil},
+		{"WholeAmount", Rule{RateBps: 10_000}, math.MaxInt64, math.MaxInt64, nil},
+		{"Overflows", Rule{Flat: 1, RateBps: 10_000}, math.MaxInt64, 0, ErrFeeOverflows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.Fee(tt.amount)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr bool
+	}{
+		{"Valid", Rule{Flat: 10, RateBps: 100, Min: 10, Max: 100}, false},
+		{"NegativeFlat", Rule{Flat: -1}, true},
+		{"NegativeRate", Rule{RateBps: -1}, true},
+		{"RateOverWhole", Rule{RateBps: 10_001}, true},
+		{"MaxBelowMin", Rule{Min: 10, Max: 5}, true},
+		{"NoMax", Rule{Min: 10}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
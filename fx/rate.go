@@ -0,0 +1,112 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"simple-bank/util"
+	"time"
+)
+
+var (
+	ErrRateNotFound        = errors.New("exchange rate not found")
+	ErrConversionTooSmall  = errors.New("amount is too small to convert")
+	ErrConversionOverflows = errors.New("converted amount is too large")
+)
+
+// Rates are plain decimals, the same format Postgres `numeric` accepts and returns
+var isDecimal = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`).MatchString
+
+// One unit of `From` is worth `Value` units of `To`, from `EffectiveAt` on
+type Rate struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// A decimal string, so the rate is stored and applied without float rounding
+	Value       string    `json:"rate"`
+	EffectiveAt time.Time `json:"effective_at"`
+}
+
+type RateProvider interface {
+	// Returns the latest rate from `from` to `to` that is effective at `at`, or `ErrRateNotFound`
+	GetRate(ctx context.Context, from string, to string, at time.Time) (Rate, error)
+}
+
+func (rate Rate) Validate() error {
+	if !util.IsSupportedCurrency(rate.From) {
+		return fmt.Errorf("unsupported currency: %s", rate.From)
+	}
+	if !util.IsSupportedCurrency(rate.To) {
+		return fmt.Errorf("unsupported currency: %s", rate.To)
+	}
+	if rate.From == rate.To {
+		return fmt.Errorf("rate must be between different currencies: %s", rate.From)
+	}
+	if _, err := rate.value(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Converts an amount in the minor units of `From` into the minor units of `To`.
+// The result is rounded down, so a conversion never creates money.
+func (rate Rate) Convert(amount int64) (int64, error) {
+	value, err := rate.value()
+	if err != nil {
+		return 0, err
+	}
+
+	scale, err := minorUnitsScale(rate.From, rate.To)
+	if err != nil {
+		return 0, err
+	}
+	value.Mul(value, scale)
+
+	converted := new(big.Int).Mul(value.Num(), big.NewInt(amount))
+	converted.Quo(converted, value.Denom())
+
+	if !converted.IsInt64() {
+		return 0, ErrConversionOverflows
+	}
+	if converted.Int64() <= 0 {
+		return 0, fmt.Errorf("%w: %s", ErrConversionTooSmall, util.Money{Amount: amount, Currency: rate.From})
+	}
+	return converted.Int64(), nil
+}
+
+func (rate Rate) value() (*big.Rat, error) {
+	if !isDecimal(rate.Value) {
+		return nil, fmt.Errorf("rate must be a decimal number: %q", rate.Value)
+	}
+
+	value, ok := new(big.Rat).SetString(rate.Value)
+	if !ok || value.Sign() <= 0 {
+		return nil, fmt.Errorf("rate must be a positive number: %q", rate.Value)
+	}
+	return value, nil
+}
+
+// The rate is per whole unit, so it is scaled by the difference in minor units,
+// eg: at 1 USD = 150 JPY one cent is worth 1.5 yen.
+// Disabled currencies are still known, so their pending transfers can be converted.
+func minorUnitsScale(from string, to string) (*big.Rat, error) {
+	fromCurrency, ok := util.LookupCurrency(from)
+	if !ok {
+		return nil, fmt.Errorf("unknown currency: %s", from)
+	}
+	toCurrency, ok := util.LookupCurrency(to)
+	if !ok {
+		return nil, fmt.Errorf("unknown currency: %s", to)
+	}
+
+	scale := big.NewRat(1, 1)
+	ten := big.NewRat(10, 1)
+	for units := fromCurrency.MinorUnits; units < toCurrency.MinorUnits; units++ {
+		scale.Mul(scale, ten)
+	}
+	for units := toCurrency.MinorUnits; units < fromCurrency.MinorUnits; units++ {
+		scale.Quo(scale, ten)
+	}
+	return scale, nil
+}
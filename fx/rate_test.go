@@ -0,0 +1,111 @@
+package fx
+
+import (
+	"math"
+	"simple-bank/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    string
+		amount  int64
+		want    int64
+		wantErr error
+	}{
+		{"Whole", "2", 10, 20, nil},
+		{"Fraction", "0.92", 100, 92, nil},
+		{"RoundsDown", "0.925", 10, 9, nil},
+		{"TooSmall", "0.5", 1, 0, ErrConversionTooSmall},
+		{"Overflows", "2", math.MaxInt64, 0, ErrConversionOverflows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate := Rate{From: util.USD, To: util.EUR, Value: tt.rate}
+
+			got, err := rate.Convert(tt.amount)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConvertMinorUnits(t *testing.T) {
+	util.SetCurrencies([]util.Currency{
+		{Code: util.USD, MinorUnits: 2, Enabled: true},
+		{Code: "JPY", MinorUnits: 0, Enabled: true},
+		{Code: "BHD", MinorUnits: 3, Enabled: true},
+	})
+	t.Cleanup(func() {
+		util.SetCurrencies([]util.Currency{
+			{Code: util.USD, MinorUnits: 2, Enabled: true},
+			{Code: util.EUR, MinorUnits: 2, Enabled: true},
+			{Code: util.INR, MinorUnits: 2, Enabled: true},
+			{Code: util.CAD, MinorUnits: 2, Enabled: true},
+		})
+	})
+
+	tests := []struct {
+		name    string
+		rate    Rate
+		amount  int64
+		want    int64
+		wantErr error
+	}{
+		// 10.00 USD is 1500 JPY
+		{"ToFewerMinorUnits", Rate{From: util.USD, To: "JPY", Value: "150"}, 1000, 1500, nil},
+		// 1500 JPY is 10.00 USD
+		{"ToMoreMinorUnits", Rate{From: "JPY", To: util.USD, Value: "0.0066667"}, 1500, 1000, nil},
+		// 1.000 BHD is 2.65 USD
+		{"FromThreeMinorUnits", Rate{From: "BHD", To: util.USD, Value: "2.65"}, 1000, 265, nil},
+		// One cent is worth less than a yen at this rate
+		{"TooSmall", Rate{From: util.USD, To: "JPY", Value: "50"}, 1, 0, ErrConversionTooSmall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rate.Convert(tt.amount)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    Rate
+		wantErr bool
+	}{
+		{"Valid", Rate{From: util.USD, To: util.EUR, Value: "0.92"}, false},
+		{"SameCurrency", Rate{From: util.USD, To: util.USD, Value: "1"}, true},
+		{"UnsupportedCurrency", Rate{From: util.USD, To: "XYZ", Value: "1"}, true},
+		{"Zero", Rate{From: util.USD, To: util.EUR, Value: "0"}, true},
+		{"Negative", Rate{From: util.USD, To: util.EUR, Value: "-1"}, true},
+		{"Fraction", Rate{From: util.USD, To: util.EUR, Value: "23/25"}, true},
+		{"Exponent", Rate{From: util.USD, To: util.EUR, Value: "9.2e-1"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rate.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+type pair struct {
+	from string
+	to   string
+}
+
+// Serves a fixed table of rates from memory, for tests and offline use
+type StaticProvider struct {
+	// Sorted by `EffectiveAt`, oldest first
+	rates map[pair][]Rate
+}
+
+func NewStaticProvider(rates ...Rate) (RateProvider, error) {
+	provider := &StaticProvider{
+		rates: make(map[pair][]Rate),
+	}
+
+	for _, rate := range rates {
+		if err := rate.Validate(); err != nil {
+			return nil, err
+		}
+		key := pair{rate.From, rate.To}
+		provider.rates[key] = append(provider.rates[key], rate)
+	}
+
+	for _, history := range provider.rates {
+		sort.Slice(history, func(i, j int) bool {
+			return history[i].EffectiveAt.Before(history[j].EffectiveAt)
+		})
+	}
+
+	return provider, nil
+}
+
+// Loads the rates from a JSON file holding a list of `Rate`, e.g.
+// `[{"from": "USD", "to": "EUR", "rate": "0.92", "effective_at": "2024-01-01T00:00:00Z"}]`
+func NewFileProvider(path string) (RateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read rates file: %w", err)
+	}
+
+	var rates []Rate
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("cannot parse rates file: %w", err)
+	}
+
+	return NewStaticProvider(rates...)
+}
+
+func (provider *StaticProvider) GetRate(ctx context.Context, from string, to string, at time.Time) (Rate, error) {
+	history := provider.rates[pair{from, to}]
+
+	// The first rate that is not effective yet, the one before it is the current one
+	i := sort.Search(len(history), func(i int) bool {
+		return history[i].EffectiveAt.After(at)
+	})
+	if i == 0 {
+		return Rate{}, fmt.Errorf("%w: %s to %s", ErrRateNotFound, from, to)
+	}
+
+	return history[i-1], nil
+}
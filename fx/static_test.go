@@ -0,0 +1,53 @@
+package fx
+
+import (
+	"context"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileProvider(t *testing.T) {
+	provider, err := NewFileProvider("testdata/rates.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		at      time.Time
+		want    string
+		wantErr bool
+	}{
+		{"BeforeFirstRate", util.USD, util.EUR, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), "", true},
+		{"FirstRate", util.USD, util.EUR, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), "0.9", false},
+		{"EffectiveExactly", util.USD, util.EUR, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), "0.92", false},
+		{"LatestRate", util.USD, util.EUR, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "0.92", false},
+		{"OtherDirection", util.EUR, util.USD, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "1.08", false},
+		{"UnknownPair", util.USD, util.CAD, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := provider.GetRate(context.Background(), tt.from, tt.to, tt.at)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrRateNotFound)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, rate.Value)
+		})
+	}
+}
+
+func TestStaticProviderInvalidRate(t *testing.T) {
+	_, err := NewStaticProvider(Rate{From: util.USD, To: util.EUR, Value: "0"})
+	require.Error(t, err)
+}
+
+func TestFileProviderMissingFile(t *testing.T) {
+	_, err := NewFileProvider("testdata/missing.json")
+	require.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package fx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	db "simple-bank/db/sqlc"
+	"simple-bank/util"
+	"time"
+)
+
+// Reads rates from the `rates` table
+type StoreProvider struct {
+	q db.Querier
+}
+
+func NewStoreProvider(q db.Querier) RateProvider {
+	return &StoreProvider{q: q}
+}
+
+// The provider the servers use, the `rates` table unless `FX_RATES_FILE` is set
+func NewProvider(config util.Config, q db.Querier) (RateProvider, error) {
+	if config.FXRatesFile != "" {
+		return NewFileProvider(config.FXRatesFile)
+	}
+	return NewStoreProvider(q), nil
+}
+
+func (provider *StoreProvider) GetRate(ctx context.Context, from string, to string, at time.Time) (Rate, error) {
+	rate, err := provider.q.GetEffectiveRate(ctx, db.GetEffectiveRateParams{
+		BaseCurrency:  from,
+		QuoteCurrency: to,
+		At:            at,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Rate{}, fmt.Errorf("%w: %s to %s", ErrRateNotFound, from, to)
+		}
+		return Rate{}, err
+	}
+
+	return Rate{
+		From:        rate.BaseCurrency,
+		To:          rate.QuoteCurrency,
+		Value:       rate.Rate,
+		EffectiveAt: rate.EffectiveAt,
+	}, nil
+}
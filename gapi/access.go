@@ -0,0 +1,98 @@
+package gapi
+
+import (
+	"context"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Who may call an RPC, checked before the handler runs
+type methodAccess struct {
+	// No access token is needed
+	public bool
+	// Required on top of a valid access token, empty when any role may call it
+	permission rbac.Permission
+}
+
+var (
+	publicAccess        = methodAccess{public: true}
+	authenticatedAccess = methodAccess{}
+)
+
+func requirePermission(permission rbac.Permission) methodAccess {
+	return methodAccess{permission: permission}
+}
+
+// Every RPC must be listed here, a method without an entry is rejected.
+// Handlers still check ownership, which depends on the resource being accessed.
+var methodAccessTable = map[string]methodAccess{
+	pb.SimpleBank_CreateUser_FullMethodName:                  publicAccess,
+	pb.SimpleBank_LoginUser_FullMethodName:                   publicAccess,
+	pb.SimpleBank_RenewAccessToken_FullMethodName:            publicAccess,
+	pb.SimpleBank_LogoutUser_FullMethodName:                  publicAccess,
+	pb.SimpleBank_VerifyEmail_FullMethodName:                 publicAccess,
+	pb.SimpleBank_UpdateUser_FullMethodName:                  authenticatedAccess,
+	pb.SimpleBank_ListSessions_FullMethodName:                authenticatedAccess,
+	pb.SimpleBank_RevokeSession_FullMethodName:               authenticatedAccess,
+	pb.SimpleBank_RevokeAllSessions_FullMethodName:           authenticatedAccess,
+	pb.SimpleBank_CreateAccount_FullMethodName:               authenticatedAccess,
+	pb.SimpleBank_GetAccount_FullMethodName:                  authenticatedAccess,
+	pb.SimpleBank_ListAccounts_FullMethodName:                authenticatedAccess,
+	pb.SimpleBank_ListAccountEntries_FullMethodName:          authenticatedAccess,
+	pb.SimpleBank_ListAccountTransfers_FullMethodName:        authenticatedAccess,
+	pb.SimpleBank_CreateTransfer_FullMethodName:              authenticatedAccess,
+	pb.SimpleBank_CompleteTransfer_FullMethodName:            authenticatedAccess,
+	pb.SimpleBank_CancelTransfer_FullMethodName:              authenticatedAccess,
+	pb.SimpleBank_ListCurrencies_FullMethodName:              authenticatedAccess,
+	pb.SimpleBank_CreateScheduledTransfer_FullMethodName:     authenticatedAccess,
+	pb.SimpleBank_GetScheduledTransfer_FullMethodName:        authenticatedAccess,
+	pb.SimpleBank_ListScheduledTransfers_FullMethodName:      authenticatedAccess,
+	pb.SimpleBank_UpdateScheduledTransfer_FullMethodName:     authenticatedAccess,
+	pb.SimpleBank_DeleteScheduledTransfer_FullMethodName:     authenticatedAccess,
+	pb.SimpleBank_PlaceHold_FullMethodName:                   authenticatedAccess,
+	pb.SimpleBank_CaptureHold_FullMethodName:                 authenticatedAccess,
+	pb.SimpleBank_ReleaseHold_FullMethodName:                 authenticatedAccess,
+	pb.SimpleBank_GetAccountTransferLimits_FullMethodName:    authenticatedAccess,
+	pb.SimpleBank_GetTransferFee_FullMethodName:              authenticatedAccess,
+	pb.SimpleBank_Deposit_FullMethodName:                     requirePermission(rbac.MoveCash),
+	pb.SimpleBank_Withdraw_FullMethodName:                    requirePermission(rbac.MoveCash),
+	pb.SimpleBank_CreateCurrency_FullMethodName:              requirePermission(rbac.ManageCurrencies),
+	pb.SimpleBank_UpdateCurrency_FullMethodName:              requirePermission(rbac.ManageCurrencies),
+	pb.SimpleBank_ReverseTransfer_FullMethodName:             requirePermission(rbac.ReverseTransfers),
+	pb.SimpleBank_SetTransferLimit_FullMethodName:            requirePermission(rbac.ManageLimits),
+	pb.SimpleBank_UpdateAccountOverdraftLimit_FullMethodName: requirePermission(rbac.ManageOverdrafts),
+}
+
+// Rejects a call the caller's role can never make, before it reaches the handler
+func (server *Server) AuthorizationInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	access, ok := methodAccessTable[info.FullMethod]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "no access rule for method %s", info.FullMethod)
+	}
+
+	if access.public {
+		return handler(ctx, req)
+	}
+
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if access.permission != "" {
+		if err := rbac.Authorize(authPayload.Role, access.permission); err != nil {
+			return nil, status.Errorf(codes.PermissionDenied, "method %s requires %s: %v", info.FullMethod, access.permission, err)
+		}
+	}
+
+	return handler(ctx, req)
+}
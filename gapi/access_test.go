@@ -0,0 +1,117 @@
+package gapi
+
+import (
+	"context"
+	mockdb "simple-bank/db/mock"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestMethodAccessTableCoversService(t *testing.T) {
+	for _, method := range pb.SimpleBank_ServiceDesc.Methods {
+		fullMethod := "/" + pb.SimpleBank_ServiceDesc.ServiceName + "/" + method.MethodName
+		_, ok := methodAccessTable[fullMethod]
+		require.True(t, ok, "missing access rule for %s", fullMethod)
+	}
+}
+
+func TestAuthorizationInterceptor(t *testing.T) {
+	username := util.RandomOwner()
+
+	testCases := []struct {
+		name         string
+		fullMethod   string
+		buildContext func(t *testing.T, tokenMaker token.Maker) context.Context
+		called       bool
+		code         codes.Code
+	}{
+		{
+			name:       "Public",
+			fullMethod: pb.SimpleBank_LoginUser_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			called: true,
+			code:   codes.OK,
+		},
+		{
+			name:       "Authenticated",
+			fullMethod: pb.SimpleBank_GetAccount_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, rbac.DepositorRole, time.Minute)
+			},
+			called: true,
+			code:   codes.OK,
+		},
+		{
+			name:       "NoAuthorization",
+			fullMethod: pb.SimpleBank_GetAccount_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:       "Banker",
+			fullMethod: pb.SimpleBank_Deposit_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, rbac.BankerRole, time.Minute)
+			},
+			called: true,
+			code:   codes.OK,
+		},
+		{
+			name:       "DepositorWithoutPermission",
+			fullMethod: pb.SimpleBank_Deposit_FullMethodName,
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, rbac.DepositorRole, time.Minute)
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:       "UnknownMethod",
+			fullMethod: "/pb.SimpleBank/Unknown",
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, username, rbac.BankerRole, time.Minute)
+			},
+			code: codes.PermissionDenied,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			called := false
+			handler := func(ctx context.Context, req any) (any, error) {
+				called = true
+				return nil, nil
+			}
+
+			info := &grpc.UnaryServerInfo{FullMethod: tc.fullMethod}
+			_, err := server.AuthorizationInterceptor(ctx, nil, info, handler)
+			require.Equal(t, tc.called, called)
+			if tc.code == codes.OK {
+				require.NoError(t, err)
+				return
+			}
+			requireStatusCode(t, err, tc.code)
+		})
+	}
+}
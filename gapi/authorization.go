@@ -3,6 +3,7 @@ package gapi
 import (
 	"context"
 	"fmt"
+	"simple-bank/rbac"
 	"simple-bank/token"
 	"strings"
 
@@ -42,5 +43,15 @@ func (server *Server) authorizeUser(ctx context.Context) (*token.Payload, error)
 		return nil, fmt.Errorf("invalid access token: %v", err)
 	}
 
+	if !rbac.IsSupportedRole(payload.Role) {
+		return nil, fmt.Errorf("unsupported role: %q", payload.Role)
+	}
+
+	// The token stays valid until it expires, so reject it once its session is blocked
+	err = server.sessionCache.Check(ctx, payload.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session: %v", err)
+	}
+
 	return payload, nil
 }
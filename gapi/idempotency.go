@@ -0,0 +1,162 @@
+package gapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	db "simple-bank/db/sqlc"
+	"simple-bank/val"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// The gateway forwards the `Idempotency-Key` HTTP header under this metadata key
+const idempotencyKeyHeader = "idempotency-key"
+
+// Runs `handler` at most once per idempotency key of the user.
+// A retry with the same key and request returns the saved response of the first call,
+// a retry with the same key and a different request fails with `AlreadyExists`.
+// Requests without an idempotency key just run the handler.
+// A key claimed by a request that never finished rejects its retries with `Aborted`,
+// since that request may have committed before it stopped.
+func (server *Server) runIdempotent(
+	ctx context.Context,
+	username string,
+	method string,
+	req proto.Message,
+	handler func() (proto.Message, error),
+) (proto.Message, error) {
+	key := idempotencyKeyFromContext(ctx)
+	if key == "" {
+		return handler()
+	}
+
+	if err := val.ValidateIdempotencyKey(key); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{
+			fieldViolation(idempotencyKeyHeader, err),
+		})
+	}
+
+	requestHash, err := hashRequest(method, req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash request: %v", err)
+	}
+
+	idempotencyKey, err := server.store.CreateIdempotencyKey(ctx, db.CreateIdempotencyKeyParams{
+		Username:    username,
+		Key:         key,
+		RequestHash: requestHash,
+		ClaimID:     uuid.NullUUID{UUID: uuid.New(), Valid: true},
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, status.Errorf(codes.Internal, "failed to create idempotency key: %v", err)
+		}
+
+		// The key was used before
+		return server.replayIdempotentResponse(ctx, username, key, requestHash)
+	}
+
+	// The key is released and the response saved even if the client went away in the meantime
+	cleanupCtx := context.WithoutCancel(ctx)
+
+	rsp, err := handler()
+	if err != nil {
+		// A failed request can be retried with the same key
+		deleteErr := server.store.DeleteIdempotencyKey(cleanupCtx, db.DeleteIdempotencyKeyParams{
+			Username: idempotencyKey.Username,
+			Key:      idempotencyKey.Key,
+			ClaimID:  idempotencyKey.ClaimID,
+		})
+		if deleteErr != nil {
+			log.Error().Err(deleteErr).Str("method", method).Msg("cannot delete idempotency key")
+		}
+		return nil, err
+	}
+
+	// Wrapped in `Any` so the replay knows which response type to unmarshal into
+	responseBody, err := anypb.New(rsp)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to serialize response: %v", err)
+	}
+
+	data, err := proto.Marshal(responseBody)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to serialize response: %v", err)
+	}
+
+	_, err = server.store.UpdateIdempotencyKeyResponse(cleanupCtx, db.UpdateIdempotencyKeyResponseParams{
+		Username:     idempotencyKey.Username,
+		Key:          idempotencyKey.Key,
+		ClaimID:      idempotencyKey.ClaimID,
+		ResponseCode: sql.NullInt32{Int32: int32(codes.OK), Valid: true},
+		ResponseBody: data,
+	})
+	if err != nil {
+		// The handler already committed, so the client still gets its response.
+		// The key stays claimed, so a retry is rejected rather than run again.
+		log.Error().Err(err).Str("method", method).Msg("cannot save idempotent response")
+	}
+
+	return rsp, nil
+}
+
+func (server *Server) replayIdempotentResponse(ctx context.Context, username string, key string, requestHash string) (proto.Message, error) {
+	idempotencyKey, err := server.store.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+		Username: username,
+		Key:      key,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get idempotency key: %v", err)
+	}
+
+	if idempotencyKey.RequestHash != requestHash {
+		return nil, status.Errorf(codes.AlreadyExists, "idempotency key was already used with a different request")
+	}
+
+	if !idempotencyKey.ResponseCode.Valid {
+		return nil, status.Errorf(codes.Aborted, "a request with this idempotency key is being processed or did not finish")
+	}
+
+	var responseBody anypb.Any
+	if err := proto.Unmarshal(idempotencyKey.ResponseBody, &responseBody); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read idempotent response: %v", err)
+	}
+
+	rsp, err := responseBody.UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read idempotent response: %v", err)
+	}
+
+	return rsp, nil
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(idempotencyKeyHeader); len(keys) > 0 {
+			return keys[0]
+		}
+	}
+	return ""
+}
+
+func hashRequest(method string, req proto.Message) (string, error) {
+	// Deterministic marshalling so the same request always has the same hash
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(method + "\n"))
+	hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,208 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type eqCreateIdempotencyKeyParamsMatcher struct {
+	arg db.CreateIdempotencyKeyParams
+}
+
+// Each request claims the key with a new claim id, so only its presence is checked
+func (e eqCreateIdempotencyKeyParamsMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.CreateIdempotencyKeyParams)
+	if !ok || !arg.ClaimID.Valid {
+		return false
+	}
+
+	e.arg.ClaimID = arg.ClaimID
+	return reflect.DeepEqual(e.arg, arg)
+}
+
+func (e eqCreateIdempotencyKeyParamsMatcher) String() string {
+	return fmt.Sprintf("matches arg %v with a claim id", e.arg)
+}
+
+func EqCreateIdempotencyKeyParams(arg db.CreateIdempotencyKeyParams) gomock.Matcher {
+	return eqCreateIdempotencyKeyParamsMatcher{arg}
+}
+
+type eqUpdateIdempotencyKeyClaimMatcher struct {
+	claimID uuid.NullUUID
+}
+
+// The response is only saved under the claim of the request that ran the handler
+func (e eqUpdateIdempotencyKeyClaimMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.UpdateIdempotencyKeyResponseParams)
+	return ok && arg.ClaimID == e.claimID && arg.ResponseCode.Valid
+}
+
+func (e eqUpdateIdempotencyKeyClaimMatcher) String() string {
+	return fmt.Sprintf("saves a response under claim %v", e.claimID)
+}
+
+func EqUpdateIdempotencyKeyClaim(claimID uuid.NullUUID) gomock.Matcher {
+	return eqUpdateIdempotencyKeyClaimMatcher{claimID}
+}
+
+func TestRunIdempotent(t *testing.T) {
+	username := "user"
+	key := "7d5c1f0e-key"
+	req := &pb.DepositRequest{AccountId: 1, Amount: 10}
+	requestHash, err := hashRequest("Deposit", req)
+	require.NoError(t, err)
+
+	claimID := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	idempotencyKey := db.IdempotencyKey{Username: username, Key: key, RequestHash: requestHash, ClaimID: claimID}
+	handlerErr := status.Errorf(codes.Internal, "handler failed")
+
+	testCases := []struct {
+		name          string
+		cancel        bool
+		handlerErr    error
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, rsp proto.Message, err error)
+	}{
+		{
+			name: "FirstRequest",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), EqCreateIdempotencyKeyParams(db.CreateIdempotencyKeyParams{
+						Username:    username,
+						Key:         key,
+						RequestHash: requestHash,
+					})).
+					Times(1).
+					Return(idempotencyKey, nil)
+				store.EXPECT().
+					UpdateIdempotencyKeyResponse(gomock.Any(), EqUpdateIdempotencyKeyClaim(claimID)).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, rsp proto.Message, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, rsp)
+			},
+		},
+		{
+			name:   "CancelledRequestReleasesKey",
+			cancel: true,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(idempotencyKey, nil)
+				store.EXPECT().
+					DeleteIdempotencyKey(gomock.Any(), gomock.Eq(db.DeleteIdempotencyKeyParams{
+						Username: username,
+						Key:      key,
+						ClaimID:  claimID,
+					})).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, _ db.DeleteIdempotencyKeyParams) error {
+						// The cancellation of the request must not stop the cleanup
+						return ctx.Err()
+					})
+			},
+			checkResponse: func(t *testing.T, rsp proto.Message, err error) {
+				requireStatusCode(t, err, codes.Canceled)
+			},
+		},
+		{
+			name:       "DeleteFails",
+			handlerErr: handlerErr,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(idempotencyKey, nil)
+				store.EXPECT().
+					DeleteIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, rsp proto.Message, err error) {
+				require.ErrorIs(t, err, handlerErr)
+			},
+		},
+		{
+			name: "SaveFails",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(idempotencyKey, nil)
+				store.EXPECT().
+					UpdateIdempotencyKeyResponse(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, rsp proto.Message, err error) {
+				// The handler already committed, so its response still goes back to the client
+				require.NoError(t, err)
+				require.NotNil(t, rsp)
+			},
+		},
+		{
+			// The claim is never taken over, the first request may have committed before it stopped
+			name: "ClaimedWithoutResponse",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IdempotencyKey{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetIdempotencyKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(idempotencyKey, nil)
+			},
+			checkResponse: func(t *testing.T, rsp proto.Message, err error) {
+				requireStatusCode(t, err, codes.Aborted)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx = metadata.NewIncomingContext(ctx, metadata.MD{idempotencyKeyHeader: []string{key}})
+
+			rsp, err := server.runIdempotent(ctx, username, "Deposit", req, func() (proto.Message, error) {
+				if tc.cancel {
+					cancel()
+					return nil, status.FromContextError(ctx.Err()).Err()
+				}
+				if tc.handlerErr != nil {
+					return nil, tc.handlerErr
+				}
+				return &pb.DepositResponse{}, nil
+			})
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
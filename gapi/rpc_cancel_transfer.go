@@ -0,0 +1,53 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CancelTransfer(ctx context.Context, req *pb.CancelTransferRequest) (*pb.CancelTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCancelTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, _, err = server.getOwnedTransfer(ctx, req.GetId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := server.store.CancelTransferTx(ctx, db.CancelTransferTxParams{
+		TransferID: req.GetId(),
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrTransferNotPending) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to cancel transfer: %v", err)
+	}
+
+	rsp := &pb.CancelTransferResponse{
+		Transfer: convertTransfer(transfer),
+	}
+
+	return rsp, nil
+}
+
+func validateCancelTransferRequest(req *pb.CancelTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	return violations
+}
@@ -0,0 +1,204 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCancelTransferAPI(t *testing.T) {
+	payer := util.RandomOwner()
+	payee := util.RandomOwner()
+	banker := util.RandomOwner()
+
+	account := randomAccount(payer)
+	toAccount := randomAccount(payee)
+	toAccount.ID = account.ID + 1
+	transfer := randomPendingTransfer(account, toAccount)
+
+	testCases := []struct {
+		name          string
+		req           *pb.CancelTransferRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CancelTransferResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CancelTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				cancelled := transfer
+				cancelled.Status = db.TransferStatusCancelled
+				store.EXPECT().
+					CancelTransferTx(gomock.Any(), gomock.Eq(db.CancelTransferTxParams{TransferID: transfer.ID})).
+					Times(1).
+					Return(cancelled, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payer, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CancelTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, transfer.ID, res.GetTransfer().GetId())
+				require.Equal(t, string(db.TransferStatusCancelled), res.GetTransfer().GetStatus())
+			},
+		},
+		{
+			// The payee can't refuse a pending transfer on the payer's behalf
+			name: "NotOwner",
+			req: &pb.CancelTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CancelTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payee, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CancelTransferResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name: "Banker",
+			req: &pb.CancelTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CancelTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CancelTransferResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name: "NotPending",
+			req: &pb.CancelTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					CancelTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Transfer{}, fmt.Errorf("%w: transfer is %s", db.ErrTransferNotPending, db.TransferStatusCompleted))
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payer, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CancelTransferResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.CancelTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(db.Transfer{}, sql.ErrNoRows)
+				store.EXPECT().CancelTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payer, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CancelTransferResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "InternalError",
+			req: &pb.CancelTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					CancelTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Transfer{}, sql.ErrConnDone)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payer, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CancelTransferResponse, err error) {
+				requireStatusCode(t, err, codes.Internal)
+			},
+		},
+		{
+			name: "InvalidId",
+			req: &pb.CancelTransferRequest{
+				Id: 0,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CancelTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payer, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CancelTransferResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.CancelTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CancelTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.CancelTransferResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.CancelTransfer(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
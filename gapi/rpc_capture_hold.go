@@ -0,0 +1,104 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func (server *Server) CaptureHold(ctx context.Context, req *pb.CaptureHoldRequest) (*pb.CaptureHoldResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCaptureHoldRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.getSettleableHold(ctx, req.GetId(), authPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := server.runIdempotent(ctx, authPayload.Username, "CaptureHold", req, func() (proto.Message, error) {
+		result, err := server.store.CaptureHoldTx(ctx, db.CaptureHoldTxParams{
+			HoldID: req.GetId(),
+			Amount: req.GetAmount(),
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrHoldNotActive) || errors.Is(err, db.ErrHoldExpired) || errors.Is(err, db.ErrInsufficientFunds) {
+				return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+			}
+			if errors.Is(err, db.ErrLimitExceeded) {
+				return nil, status.Errorf(codes.ResourceExhausted, "%v", err)
+			}
+			if errors.Is(err, db.ErrCaptureExceedsHold) {
+				return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to capture hold: %v", err)
+		}
+
+		return &pb.CaptureHoldResponse{
+			Hold:        convertHold(result.Hold),
+			Transfer:    convertTransfer(result.Transfer.Transfer),
+			FromAccount: convertAccount(result.Transfer.FromAccount),
+			ToAccount:   convertAccount(result.Transfer.ToAccount),
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.(*pb.CaptureHoldResponse), nil
+}
+
+// Returns a gRPC status error if the hold doesn't exist or the user can't capture or release it.
+// Holds are settled by the owner of the to account, or by a role that can manage any hold.
+func (server *Server) getSettleableHold(ctx context.Context, holdID int64, authPayload *token.Payload) (db.Hold, error) {
+	hold, err := server.store.GetHold(ctx, holdID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return hold, status.Errorf(codes.NotFound, "hold not found: %v", err)
+		}
+		return hold, status.Errorf(codes.Internal, "failed to get hold: %v", err)
+	}
+
+	if rbac.Can(authPayload.Role, rbac.ManageHolds) {
+		return hold, nil
+	}
+
+	toAccount, err := server.findAccount(ctx, hold.ToAccountID)
+	if err != nil {
+		return hold, err
+	}
+
+	if toAccount.Owner != authPayload.Username {
+		return hold, status.Errorf(codes.PermissionDenied, "hold isn't payable to the authenticated user")
+	}
+
+	return hold, nil
+}
+
+func validateCaptureHoldRequest(req *pb.CaptureHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if req.Amount != nil {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+	return violations
+}
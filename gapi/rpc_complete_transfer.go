@@ -0,0 +1,109 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// A pending cross-currency transfer is converted with the rate at completion
+func (server *Server) CompleteTransfer(ctx context.Context, req *pb.CompleteTransferRequest) (*pb.CompleteTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCompleteTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	transfer, fromAccount, err := server.getOwnedTransfer(ctx, req.GetId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	toAccount, err := server.findAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := server.runIdempotent(ctx, authPayload.Username, "CompleteTransfer", req, func() (proto.Message, error) {
+		arg := db.CompleteTransferTxParams{
+			TransferID: transfer.ID,
+		}
+
+		if fromAccount.Currency != toAccount.Currency {
+			toAmount, rate, err := server.convert(ctx, transfer.Amount, fromAccount.Currency, toAccount.Currency)
+			if err != nil {
+				return nil, err
+			}
+			arg.ToAmount = toAmount
+			arg.ExchangeRate = rate.Value
+		}
+
+		result, err := server.store.CompleteTransferTx(ctx, arg)
+		if err != nil {
+			// The transfer is failed for good when the from account can't cover it
+			if errors.Is(err, db.ErrTransferNotPending) || errors.Is(err, db.ErrInsufficientFunds) {
+				return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+			}
+			// The transfer stays pending and can be completed once the limit resets
+			if errors.Is(err, db.ErrLimitExceeded) {
+				return nil, status.Errorf(codes.ResourceExhausted, "%v", err)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to complete transfer: %v", err)
+		}
+
+		return &pb.CompleteTransferResponse{
+			Transfer:    convertTransfer(result.Transfer),
+			FromAccount: convertAccount(result.FromAccount),
+			ToAccount:   convertAccount(result.ToAccount),
+			FromEntry:   convertEntry(result.FromEntry),
+			ToEntry:     convertEntry(result.ToEntry),
+			FeeEntry:    convertFeeEntry(result),
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.(*pb.CompleteTransferResponse), nil
+}
+
+// Returns a gRPC status error if the transfer doesn't exist or wasn't made from an account of the user
+func (server *Server) getOwnedTransfer(ctx context.Context, transferID int64, username string) (db.Transfer, db.Account, error) {
+	transfer, err := server.store.GetTransfer(ctx, transferID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return transfer, db.Account{}, status.Errorf(codes.NotFound, "transfer not found: %v", err)
+		}
+		return transfer, db.Account{}, status.Errorf(codes.Internal, "failed to get transfer: %v", err)
+	}
+
+	fromAccount, err := server.findAccount(ctx, transfer.FromAccountID)
+	if err != nil {
+		return transfer, fromAccount, err
+	}
+
+	if fromAccount.Owner != username {
+		return transfer, fromAccount, status.Errorf(codes.PermissionDenied, "from account doesn't belong to the authenticated user")
+	}
+
+	return transfer, fromAccount, nil
+}
+
+func validateCompleteTransferRequest(req *pb.CompleteTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	return violations
+}
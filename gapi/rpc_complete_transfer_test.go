@@ -0,0 +1,246 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCompleteTransferAPI(t *testing.T) {
+	payer := util.RandomOwner()
+	payee := util.RandomOwner()
+	banker := util.RandomOwner()
+
+	account := randomAccount(payer)
+	toAccount := randomAccount(payee)
+	toAccount.ID = account.ID + 1
+	transfer := randomPendingTransfer(account, toAccount)
+
+	testCases := []struct {
+		name          string
+		req           *pb.CompleteTransferRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CompleteTransferResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CompleteTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+
+				completed := transfer
+				completed.Status = db.TransferStatusCompleted
+				store.EXPECT().
+					CompleteTransferTx(gomock.Any(), gomock.Eq(db.CompleteTransferTxParams{TransferID: transfer.ID})).
+					Times(1).
+					Return(db.TransferTxResult{
+						Transfer:    completed,
+						FromAccount: account,
+						ToAccount:   toAccount,
+						FromEntry:   db.Entry{AccountID: account.ID, Amount: -transfer.Amount},
+						ToEntry:     db.Entry{AccountID: toAccount.ID, Amount: transfer.Amount},
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payer, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CompleteTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, string(db.TransferStatusCompleted), res.GetTransfer().GetStatus())
+				require.Equal(t, -transfer.Amount, res.GetFromEntry().GetAmount())
+				require.Equal(t, transfer.Amount, res.GetToEntry().GetAmount())
+				require.Nil(t, res.GetFeeEntry())
+			},
+		},
+		{
+			name: "NotOwner",
+			req: &pb.CompleteTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CompleteTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payee, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CompleteTransferResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			// Only the payer decides whether its own pending transfer goes through
+			name: "Banker",
+			req: &pb.CompleteTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CompleteTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CompleteTransferResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name: "NotPending",
+			req: &pb.CompleteTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					CompleteTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, fmt.Errorf("%w: transfer is %s", db.ErrTransferNotPending, db.TransferStatusCancelled))
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payer, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CompleteTransferResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			req: &pb.CompleteTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					CompleteTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, db.ErrInsufficientFunds)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payer, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CompleteTransferResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "LimitExceeded",
+			req: &pb.CompleteTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+				store.EXPECT().
+					CompleteTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.TransferTxResult{}, db.ErrLimitExceeded)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payer, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CompleteTransferResponse, err error) {
+				requireStatusCode(t, err, codes.ResourceExhausted)
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.CompleteTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(db.Transfer{}, sql.ErrNoRows)
+				store.EXPECT().CompleteTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payer, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CompleteTransferResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "InvalidId",
+			req: &pb.CompleteTransferRequest{
+				Id: 0,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CompleteTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, payer, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CompleteTransferResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.CompleteTransferRequest{
+				Id: transfer.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CompleteTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.CompleteTransferResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.CompleteTransfer(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func randomPendingTransfer(account db.Account, toAccount db.Account) db.Transfer {
+	return db.Transfer{
+		ID:            util.RandomInt(1, 1000),
+		FromAccountID: account.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        util.RandomInt(1, 100),
+		Status:        db.TransferStatusPending,
+	}
+}
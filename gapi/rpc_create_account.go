@@ -0,0 +1,115 @@
+package gapi
+
+import (
+	"context"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/val"
+
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateAccountRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// Bankers open accounts for customers, depositors only for themselves
+	owner := authPayload.Username
+	if req.Owner != nil && req.GetOwner() != authPayload.Username {
+		if err := rbac.Authorize(authPayload.Role, rbac.OpenAnyAccount); err != nil {
+			return nil, status.Errorf(codes.PermissionDenied, "cannot open an account for another user: %v", err)
+		}
+		owner = req.GetOwner()
+	}
+
+	// The type decides the fees of the account, so customers can't pick a cheaper one themselves
+	if req.GetType() != "" && db.AccountType(req.GetType()) != db.AccountTypePersonal {
+		if err := rbac.Authorize(authPayload.Role, rbac.SetAccountType); err != nil {
+			return nil, status.Errorf(codes.PermissionDenied, "only bankers can open %s accounts: %v", req.GetType(), err)
+		}
+	}
+
+	rsp, err := server.runIdempotent(ctx, authPayload.Username, "CreateAccount", req, func() (proto.Message, error) {
+		arg := db.CreateAccountParams{
+			Owner:    owner,
+			Balance:  0,
+			Currency: req.GetCurrency(),
+		}
+		if req.GetType() != "" {
+			arg.Type = db.NullAccountType{AccountType: db.AccountType(req.GetType()), Valid: true}
+		}
+
+		account, err := server.store.CreateAccount(ctx, arg)
+		if err != nil {
+			if pgErr, ok := err.(*pq.Error); ok {
+				switch pgErr.Code.Name() {
+				case "unique_violation":
+					return nil, status.Errorf(codes.AlreadyExists, "account with this currency already exists: %v", err)
+				case "foreign_key_violation":
+					if owner != authPayload.Username {
+						return nil, status.Errorf(codes.NotFound, "owner not found: %v", err)
+					}
+					return nil, status.Errorf(codes.PermissionDenied, "cannot create account: %v", err)
+				}
+			}
+			return nil, status.Errorf(codes.Internal, "failed to create account: %v", err)
+		}
+
+		return &pb.CreateAccountResponse{
+			Account: convertAccount(account),
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.(*pb.CreateAccountResponse), nil
+}
+
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:               account.ID,
+		Owner:            account.Owner,
+		Balance:          account.Balance,
+		Currency:         account.Currency,
+		CreatedAt:        timestamppb.New(account.CreatedAt),
+		OverdraftLimit:   account.OverdraftLimit,
+		HeldAmount:       account.HeldAmount,
+		AvailableBalance: account.Balance - account.HeldAmount,
+		Type:             string(account.Type),
+	}
+}
+
+func validateCreateAccountRequest(req *pb.CreateAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	// Reserved usernames are rejected, so no account can be opened for the house users
+	if req.Owner != nil {
+		if err := val.ValidateUsername(req.GetOwner()); err != nil {
+			violations = append(violations, fieldViolation("owner", err))
+		}
+	}
+
+	if req.GetType() != "" {
+		if err := val.ValidateAccountType(req.GetType()); err != nil {
+			violations = append(violations, fieldViolation("type", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,252 @@
+package gapi
+
+import (
+	"context"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCreateAccountAPI(t *testing.T) {
+	banker := util.RandomOwner()
+	depositor := util.RandomOwner()
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateAccountRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreateAccountResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateAccountRequest{
+				Currency: util.USD,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAccountParams{
+					Owner:    depositor,
+					Currency: util.USD,
+				}
+				store.EXPECT().
+					CreateAccount(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Account{Owner: depositor, Currency: util.USD, Type: db.AccountTypePersonal}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAccountResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, string(db.AccountTypePersonal), res.GetAccount().GetType())
+			},
+		},
+		{
+			name: "DepositorPersonal",
+			req: &pb.CreateAccountRequest{
+				Currency: util.USD,
+				Type:     string(db.AccountTypePersonal),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAccount(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Account{Owner: depositor, Currency: util.USD, Type: db.AccountTypePersonal}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAccountResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "DepositorBusiness",
+			req: &pb.CreateAccountRequest{
+				Currency: util.USD,
+				Type:     string(db.AccountTypeBusiness),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAccountResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name: "BankerBusiness",
+			req: &pb.CreateAccountRequest{
+				Currency: util.USD,
+				Type:     string(db.AccountTypeBusiness),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAccountParams{
+					Owner:    banker,
+					Currency: util.USD,
+					Type:     db.NullAccountType{AccountType: db.AccountTypeBusiness, Valid: true},
+				}
+				store.EXPECT().
+					CreateAccount(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Account{Owner: banker, Currency: util.USD, Type: db.AccountTypeBusiness}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAccountResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, string(db.AccountTypeBusiness), res.GetAccount().GetType())
+			},
+		},
+		{
+			// Business fees only apply once a banker opens the account for the customer
+			name: "BankerBusinessForCustomer",
+			req: &pb.CreateAccountRequest{
+				Currency: util.USD,
+				Type:     string(db.AccountTypeBusiness),
+				Owner:    proto.String(depositor),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAccountParams{
+					Owner:    depositor,
+					Currency: util.USD,
+					Type:     db.NullAccountType{AccountType: db.AccountTypeBusiness, Valid: true},
+				}
+				store.EXPECT().
+					CreateAccount(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Account{Owner: depositor, Currency: util.USD, Type: db.AccountTypeBusiness}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAccountResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, depositor, res.GetAccount().GetOwner())
+				require.Equal(t, string(db.AccountTypeBusiness), res.GetAccount().GetType())
+			},
+		},
+		{
+			name: "DepositorOwnName",
+			req: &pb.CreateAccountRequest{
+				Currency: util.USD,
+				Owner:    proto.String(depositor),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateAccountParams{
+					Owner:    depositor,
+					Currency: util.USD,
+				}
+				store.EXPECT().
+					CreateAccount(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Account{Owner: depositor, Currency: util.USD, Type: db.AccountTypePersonal}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAccountResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "DepositorForAnotherUser",
+			req: &pb.CreateAccountRequest{
+				Currency: util.USD,
+				Owner:    proto.String(util.RandomOwner()),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAccountResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name: "OwnerNotFound",
+			req: &pb.CreateAccountRequest{
+				Currency: util.USD,
+				Owner:    proto.String(util.RandomOwner()),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateAccount(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Account{}, &pq.Error{Code: "23503"})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAccountResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			// The house users own the cash and fee accounts, nobody opens accounts for them
+			name: "ReservedOwner",
+			req: &pb.CreateAccountRequest{
+				Currency: util.USD,
+				Owner:    proto.String("fees"),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAccountResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "InvalidType",
+			req: &pb.CreateAccountRequest{
+				Currency: util.USD,
+				Type:     "savings",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateAccountResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.CreateAccount(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
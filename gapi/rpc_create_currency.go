@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"context"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/val"
+
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) CreateCurrency(ctx context.Context, req *pb.CreateCurrencyRequest) (*pb.CreateCurrencyResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := rbac.Authorize(authPayload.Role, rbac.ManageCurrencies); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "only bankers can create currencies: %v", err)
+	}
+
+	violations := validateCreateCurrencyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	currency, err := server.store.CreateCurrency(ctx, db.CreateCurrencyParams{
+		Code:       req.GetCode(),
+		MinorUnits: req.GetMinorUnits(),
+		Enabled:    req.GetEnabled(),
+	})
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok {
+			switch pgErr.Code.Name() {
+			case "unique_violation":
+				return nil, status.Errorf(codes.AlreadyExists, "currency already exists: %v", err)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create currency: %v", err)
+	}
+
+	server.reloadCurrencies(ctx)
+
+	rsp := &pb.CreateCurrencyResponse{
+		Currency: convertCurrency(currency),
+	}
+
+	return rsp, nil
+}
+
+func validateCreateCurrencyRequest(req *pb.CreateCurrencyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrencyCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+	if err := val.ValidateMinorUnits(req.GetMinorUnits()); err != nil {
+		violations = append(violations, fieldViolation("minor_units", err))
+	}
+	return violations
+}
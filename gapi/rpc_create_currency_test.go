@@ -0,0 +1,195 @@
+package gapi
+
+import (
+	"context"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCreateCurrencyAPI(t *testing.T) {
+	banker := util.RandomOwner()
+	depositor := util.RandomOwner()
+	currency := db.Currency{
+		Code:       "GBP",
+		MinorUnits: 2,
+		Enabled:    true,
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateCurrencyRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreateCurrencyResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateCurrencyRequest{
+				Code:       currency.Code,
+				MinorUnits: currency.MinorUnits,
+				Enabled:    currency.Enabled,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateCurrencyParams{
+					Code:       currency.Code,
+					MinorUnits: currency.MinorUnits,
+					Enabled:    currency.Enabled,
+				}
+				store.EXPECT().
+					CreateCurrency(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(currency, nil)
+				stubReloadCurrencies(store, currency)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateCurrencyResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, currency.Code, res.GetCurrency().GetCode())
+				require.True(t, util.IsSupportedCurrency(currency.Code))
+			},
+		},
+		{
+			name: "Depositor",
+			req: &pb.CreateCurrencyRequest{
+				Code:       currency.Code,
+				MinorUnits: currency.MinorUnits,
+				Enabled:    currency.Enabled,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateCurrencyResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name: "AlreadyExists",
+			req: &pb.CreateCurrencyRequest{
+				Code:       util.USD,
+				MinorUnits: 2,
+				Enabled:    true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCurrency(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Currency{}, &pq.Error{Code: "23505"})
+				store.EXPECT().ListCurrencies(gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateCurrencyResponse, err error) {
+				requireStatusCode(t, err, codes.AlreadyExists)
+			},
+		},
+		{
+			name: "InvalidCode",
+			req: &pb.CreateCurrencyRequest{
+				Code:       "gbp",
+				MinorUnits: 2,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateCurrencyResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.CreateCurrencyRequest{
+				Code:       currency.Code,
+				MinorUnits: currency.MinorUnits,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateCurrencyResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// The handlers reload the currency registry, which the other tests rely on
+			t.Cleanup(restoreCurrencies)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.CreateCurrency(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+// The currencies that are enabled before the `currencies` table is loaded
+func builtInCurrencies() []db.Currency {
+	currencyCodes := []string{util.CAD, util.EUR, util.INR, util.USD}
+	currencies := make([]db.Currency, len(currencyCodes))
+	for i, code := range currencyCodes {
+		currencies[i] = db.Currency{Code: code, MinorUnits: 2, Enabled: true}
+	}
+	return currencies
+}
+
+// Expects the registry reload that follows a currency change, returning the built-in currencies and `changed`
+func stubReloadCurrencies(store *mockdb.MockStore, changed db.Currency) {
+	currencies := builtInCurrencies()
+	for i := range currencies {
+		if currencies[i].Code == changed.Code {
+			currencies = append(currencies[:i], currencies[i+1:]...)
+			break
+		}
+	}
+	currencies = append(currencies, changed)
+
+	store.EXPECT().
+		ListCurrencies(gomock.Any()).
+		Times(1).
+		Return(currencies, nil)
+}
+
+func restoreCurrencies() {
+	currencies := builtInCurrencies()
+	registry := make([]util.Currency, len(currencies))
+	for i, currency := range currencies {
+		registry[i] = util.Currency{
+			Code:       currency.Code,
+			MinorUnits: currency.MinorUnits,
+			Enabled:    currency.Enabled,
+		}
+	}
+	util.SetCurrencies(registry)
+}
@@ -0,0 +1,151 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/val"
+	"simple-bank/worker"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Scheduled transfers are executed by the transfer scheduler with the same checks as `CreateTransfer`
+func (server *Server) CreateScheduledTransfer(ctx context.Context, req *pb.CreateScheduledTransferRequest) (*pb.CreateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "from account doesn't belong to the authenticated user")
+	}
+
+	// The amount is fixed up front, so cross currency scheduled transfers aren't supported
+	toAccount, err := server.validAccount(ctx, req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	err = validTransferTarget(toAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := server.runIdempotent(ctx, authPayload.Username, "CreateScheduledTransfer", req, func() (proto.Message, error) {
+		arg := db.CreateScheduledTransferParams{
+			Owner:         authPayload.Username,
+			FromAccountID: req.GetFromAccountId(),
+			ToAccountID:   req.GetToAccountId(),
+			Amount:        req.GetAmount(),
+			Currency:      req.GetCurrency(),
+			CronExpression: sql.NullString{
+				String: req.GetCronExpression(),
+				Valid:  req.CronExpression != nil,
+			},
+			IntervalSeconds: sql.NullInt64{
+				Int64: req.GetIntervalSeconds(),
+				Valid: req.IntervalSeconds != nil,
+			},
+		}
+
+		startAt := time.Now()
+		if req.StartAt != nil {
+			startAt = req.GetStartAt().AsTime()
+		}
+
+		schedule, err := worker.ScheduledTransferRecurrence(arg.CronExpression, arg.IntervalSeconds, startAt)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+
+		// A cron schedule first runs at its first occurrence from the start, the others right at the start
+		arg.NextRunAt = startAt
+		if arg.CronExpression.Valid {
+			arg.NextRunAt = schedule.Next(startAt.Add(-time.Second))
+		}
+
+		scheduledTransfer, err := server.store.CreateScheduledTransfer(ctx, arg)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create scheduled transfer: %v", err)
+		}
+
+		return &pb.CreateScheduledTransferResponse{
+			ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.(*pb.CreateScheduledTransferResponse), nil
+}
+
+func convertScheduledTransfer(scheduledTransfer db.ScheduledTransfer) *pb.ScheduledTransfer {
+	return &pb.ScheduledTransfer{
+		Id:              scheduledTransfer.ID,
+		FromAccountId:   scheduledTransfer.FromAccountID,
+		ToAccountId:     scheduledTransfer.ToAccountID,
+		Amount:          scheduledTransfer.Amount,
+		Currency:        scheduledTransfer.Currency,
+		CronExpression:  scheduledTransfer.CronExpression.String,
+		IntervalSeconds: scheduledTransfer.IntervalSeconds.Int64,
+		Status:          scheduledTransfer.Status,
+		NextRunAt:       timestamppb.New(scheduledTransfer.NextRunAt),
+		CreatedAt:       timestamppb.New(scheduledTransfer.CreatedAt),
+	}
+}
+
+func validateCreateScheduledTransferRequest(req *pb.CreateScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	if err := val.ValidateAccountId(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must be different from from_account_id")))
+	}
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	if req.CronExpression != nil {
+		if err := val.ValidateCronExpression(req.GetCronExpression()); err != nil {
+			violations = append(violations, fieldViolation("cron_expression", err))
+		}
+	}
+	if req.IntervalSeconds != nil {
+		if err := val.ValidateIntervalSeconds(req.GetIntervalSeconds()); err != nil {
+			violations = append(violations, fieldViolation("interval_seconds", err))
+		}
+		if req.CronExpression != nil {
+			violations = append(violations, fieldViolation("interval_seconds", fmt.Errorf("can't be set together with cron_expression")))
+		}
+	}
+	if req.StartAt != nil {
+		if err := req.GetStartAt().CheckValid(); err != nil {
+			violations = append(violations, fieldViolation("start_at", err))
+		}
+	}
+	return violations
+}
@@ -0,0 +1,324 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestCreateScheduledTransferAPI(t *testing.T) {
+	owner := util.RandomOwner()
+	other := util.RandomOwner()
+
+	account := randomAccount(owner)
+	toAccount := randomAccount(other)
+	toAccount.ID = account.ID + 1
+
+	houseAccount := randomAccount(db.FeesUsername)
+	houseAccount.ID = account.ID + 2
+
+	amount := int64(10)
+	startAt := time.Date(2030, time.January, 1, 8, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateScheduledTransferRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error)
+	}{
+		{
+			name: "Interval",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:   account.ID,
+				ToAccountId:     toAccount.ID,
+				Amount:          amount,
+				Currency:        util.USD,
+				IntervalSeconds: proto.Int64(3600),
+				StartAt:         timestamppb.New(startAt),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+
+				// An interval schedule first runs right at the start
+				arg := db.CreateScheduledTransferParams{
+					Owner:           owner,
+					FromAccountID:   account.ID,
+					ToAccountID:     toAccount.ID,
+					Amount:          amount,
+					Currency:        util.USD,
+					IntervalSeconds: sql.NullInt64{Int64: 3600, Valid: true},
+					NextRunAt:       startAt,
+				}
+				store.EXPECT().
+					CreateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ScheduledTransfer{
+						ID:              1,
+						Owner:           owner,
+						FromAccountID:   account.ID,
+						ToAccountID:     toAccount.ID,
+						Amount:          amount,
+						Currency:        util.USD,
+						IntervalSeconds: arg.IntervalSeconds,
+						Status:          db.ScheduledTransferActive,
+						NextRunAt:       startAt,
+					}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(3600), res.GetScheduledTransfer().GetIntervalSeconds())
+				require.Equal(t, db.ScheduledTransferActive, res.GetScheduledTransfer().GetStatus())
+				require.True(t, startAt.Equal(res.GetScheduledTransfer().GetNextRunAt().AsTime()))
+			},
+		},
+		{
+			name: "Cron",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:  account.ID,
+				ToAccountId:    toAccount.ID,
+				Amount:         amount,
+				Currency:       util.USD,
+				CronExpression: proto.String("0 9 * * *"),
+				StartAt:        timestamppb.New(startAt),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+
+				// A cron schedule first runs at its first occurrence from the start
+				arg := db.CreateScheduledTransferParams{
+					Owner:          owner,
+					FromAccountID:  account.ID,
+					ToAccountID:    toAccount.ID,
+					Amount:         amount,
+					Currency:       util.USD,
+					CronExpression: sql.NullString{String: "0 9 * * *", Valid: true},
+					NextRunAt:      time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC),
+				}
+				store.EXPECT().
+					CreateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ScheduledTransfer{ID: 1, Owner: owner, CronExpression: arg.CronExpression, NextRunAt: arg.NextRunAt}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "0 9 * * *", res.GetScheduledTransfer().GetCronExpression())
+			},
+		},
+		{
+			// Neither a cron expression nor an interval makes a one-off transfer
+			name: "OneOff",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId: account.ID,
+				ToAccountId:   toAccount.ID,
+				Amount:        amount,
+				Currency:      util.USD,
+				StartAt:       timestamppb.New(startAt),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(1).Return(toAccount, nil)
+
+				arg := db.CreateScheduledTransferParams{
+					Owner:         owner,
+					FromAccountID: account.ID,
+					ToAccountID:   toAccount.ID,
+					Amount:        amount,
+					Currency:      util.USD,
+					NextRunAt:     startAt,
+				}
+				store.EXPECT().
+					CreateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ScheduledTransfer{ID: 1, Owner: owner, NextRunAt: startAt}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "CronAndInterval",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:   account.ID,
+				ToAccountId:     toAccount.ID,
+				Amount:          amount,
+				Currency:        util.USD,
+				CronExpression:  proto.String("0 9 * * *"),
+				IntervalSeconds: proto.Int64(3600),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "InvalidCronExpression",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:  account.ID,
+				ToAccountId:    toAccount.ID,
+				Amount:         amount,
+				Currency:       util.USD,
+				CronExpression: proto.String("every day"),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "IntervalTooShort",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:   account.ID,
+				ToAccountId:     toAccount.ID,
+				Amount:          amount,
+				Currency:        util.USD,
+				IntervalSeconds: proto.Int64(59),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "NotOwner",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:   account.ID,
+				ToAccountId:     toAccount.ID,
+				Amount:          amount,
+				Currency:        util.USD,
+				IntervalSeconds: proto.Int64(3600),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(toAccount.ID)).Times(0)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, other, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:   account.ID,
+				ToAccountId:     toAccount.ID,
+				Amount:          amount,
+				Currency:        util.EUR,
+				IntervalSeconds: proto.Int64(3600),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "HouseAccount",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:   account.ID,
+				ToAccountId:     houseAccount.ID,
+				Amount:          amount,
+				Currency:        util.USD,
+				IntervalSeconds: proto.Int64(3600),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(houseAccount.ID)).Times(1).Return(houseAccount, nil)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId:   account.ID,
+				ToAccountId:     toAccount.ID,
+				Amount:          amount,
+				Currency:        util.USD,
+				IntervalSeconds: proto.Int64(3600),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.CreateScheduledTransfer(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "simple-bank/db/sqlc"
+	"simple-bank/fx"
+	"simple-bank/pb"
+	"simple-bank/val"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateCreateTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.validAccount(ctx, req.GetFromAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "from account doesn't belong to the authenticated user")
+	}
+
+	// The to account may be in another currency, the amount is converted then
+	toAccount, err := server.findAccount(ctx, req.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = validTransferTarget(toAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := server.runIdempotent(ctx, authPayload.Username, "CreateTransfer", req, func() (proto.Message, error) {
+		// The amount of a pending cross-currency transfer is converted when it is completed,
+		// but its fee is worked out now, so clients can show it before confirming
+		if req.GetPending() {
+			fee, err := server.store.GetTransferFee(ctx, fromAccount, req.GetAmount())
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get transfer fee: %v", err)
+			}
+
+			transfer, err := server.store.CreatePendingTransfer(ctx, db.CreatePendingTransferParams{
+				FromAccountID: req.GetFromAccountId(),
+				ToAccountID:   req.GetToAccountId(),
+				Amount:        req.GetAmount(),
+				Fee:           fee,
+			})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to create pending transfer: %v", err)
+			}
+
+			return &pb.CreateTransferResponse{
+				Transfer:    convertTransfer(transfer),
+				FromAccount: convertAccount(fromAccount),
+				ToAccount:   convertAccount(toAccount),
+			}, nil
+		}
+
+		arg := db.TransferTxParams{
+			FromAccountID: req.GetFromAccountId(),
+			ToAccountID:   req.GetToAccountId(),
+			Amount:        req.GetAmount(),
+		}
+
+		var result db.TransferTxResult
+		if toAccount.Currency == fromAccount.Currency {
+			result, err = server.store.TransferTx(ctx, arg)
+		} else {
+			result, err = server.crossCurrencyTransfer(ctx, arg, fromAccount.Currency, toAccount.Currency)
+		}
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return nil, err
+			}
+			if errors.Is(err, db.ErrInsufficientFunds) {
+				return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+			}
+			if errors.Is(err, db.ErrLimitExceeded) {
+				return nil, status.Errorf(codes.ResourceExhausted, "%v", err)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to transfer money: %v", err)
+		}
+
+		return &pb.CreateTransferResponse{
+			Transfer:    convertTransfer(result.Transfer),
+			FromAccount: convertAccount(result.FromAccount),
+			ToAccount:   convertAccount(result.ToAccount),
+			FromEntry:   convertEntry(result.FromEntry),
+			ToEntry:     convertEntry(result.ToEntry),
+			FeeEntry:    convertFeeEntry(result),
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.(*pb.CreateTransferResponse), nil
+}
+
+// Converts the amount with the current rate and moves it between accounts of different currencies
+func (server *Server) crossCurrencyTransfer(ctx context.Context, arg db.TransferTxParams, fromCurrency string, toCurrency string) (db.TransferTxResult, error) {
+	toAmount, rate, err := server.convert(ctx, arg.Amount, fromCurrency, toCurrency)
+	if err != nil {
+		return db.TransferTxResult{}, err
+	}
+
+	return server.store.CrossCurrencyTransferTx(ctx, db.CrossCurrencyTransferTxParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		ToAmount:      toAmount,
+		ExchangeRate:  rate.Value,
+	})
+}
+
+// Returns the amount converted with the current rate, or a gRPC status error
+func (server *Server) convert(ctx context.Context, amount int64, fromCurrency string, toCurrency string) (int64, fx.Rate, error) {
+	rate, err := server.rateProvider.GetRate(ctx, fromCurrency, toCurrency, time.Now())
+	if err != nil {
+		if errors.Is(err, fx.ErrRateNotFound) {
+			return 0, rate, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return 0, rate, status.Errorf(codes.Internal, "failed to get exchange rate: %v", err)
+	}
+
+	toAmount, err := rate.Convert(amount)
+	if err != nil {
+		return 0, rate, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	return toAmount, rate, nil
+}
+
+// Returns a gRPC status error if the account doesn't exist
+func (server *Server) findAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := server.store.GetAccount(ctx, accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found: %v", accountID, err)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account [%d]: %v", accountID, err)
+	}
+
+	return account, nil
+}
+
+// Returns a gRPC status error if the account doesn't exist or its currency doesn't match
+func (server *Server) validAccount(ctx context.Context, accountID int64, currency string) (db.Account, error) {
+	account, err := server.findAccount(ctx, accountID)
+	if err != nil {
+		return account, err
+	}
+
+	if account.Currency != currency {
+		return account, status.Errorf(codes.InvalidArgument, "account [%d] currency mismatch: %s vs %s", accountID, account.Currency, currency)
+	}
+
+	return account, nil
+}
+
+// Returns a gRPC status error if money can't be sent to the account
+func validTransferTarget(account db.Account) error {
+	if db.IsHouseAccount(account) {
+		return status.Errorf(codes.InvalidArgument, "account [%d] is a house account and can't receive transfers", account.ID)
+	}
+
+	return nil
+}
+
+func convertTransfer(transfer db.Transfer) *pb.Transfer {
+	return &pb.Transfer{
+		Id:                 transfer.ID,
+		FromAccountId:      transfer.FromAccountID,
+		ToAccountId:        transfer.ToAccountID,
+		Amount:             transfer.Amount,
+		ToAmount:           transfer.ToAmount.Int64,
+		ExchangeRate:       transfer.ExchangeRate.String,
+		ReversedTransferId: transfer.ReversedTransferID.Int64,
+		Status:             string(transfer.Status),
+		Fee:                transfer.Fee,
+		CreatedAt:          timestamppb.New(transfer.CreatedAt),
+	}
+}
+
+// Returns nil when the transfer has no fee, so the fee entry is left out of the response
+func convertFeeEntry(result db.TransferTxResult) *pb.Entry {
+	if result.Transfer.Fee == 0 {
+		return nil
+	}
+	return convertEntry(result.FeeEntry)
+}
+
+func convertEntry(entry db.Entry) *pb.Entry {
+	return &pb.Entry{
+		Id:        entry.ID,
+		AccountId: entry.AccountID,
+		Amount:    entry.Amount,
+		CreatedAt: timestamppb.New(entry.CreatedAt),
+	}
+}
+
+func validateCreateTransferRequest(req *pb.CreateTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	if err := val.ValidateAccountId(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+	if req.GetFromAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must be different from from_account_id")))
+	}
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	return violations
+}
@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The scheduled transfer is cancelled rather than deleted, so the transfers it made keep their history
+func (server *Server) DeleteScheduledTransfer(ctx context.Context, req *pb.DeleteScheduledTransferRequest) (*pb.DeleteScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateDeleteScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.getOwnedScheduledTransfer(ctx, req.GetId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	switch scheduledTransfer.Status {
+	case db.ScheduledTransferCancelled:
+		return nil, status.Errorf(codes.NotFound, "scheduled transfer not found")
+	case db.ScheduledTransferCompleted:
+		return nil, status.Errorf(codes.FailedPrecondition, "scheduled transfer is completed")
+	}
+
+	scheduledTransfer, err = server.store.UpdateScheduledTransfer(ctx, db.UpdateScheduledTransferParams{
+		ID:     scheduledTransfer.ID,
+		Status: sql.NullString{String: db.ScheduledTransferCancelled, Valid: true},
+	})
+	if err != nil {
+		// The scheduler completed it or it was cancelled after it was read
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.FailedPrecondition, "scheduled transfer is no longer active or paused")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to cancel scheduled transfer: %v", err)
+	}
+
+	rsp := &pb.DeleteScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+
+	return rsp, nil
+}
+
+func validateDeleteScheduledTransferRequest(req *pb.DeleteScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	return violations
+}
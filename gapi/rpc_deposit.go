@@ -0,0 +1,74 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func (server *Server) Deposit(ctx context.Context, req *pb.DepositRequest) (*pb.DepositResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := rbac.Authorize(authPayload.Role, rbac.MoveCash); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "only bankers can deposit money: %v", err)
+	}
+
+	violations := validateDepositRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.validAccount(ctx, req.GetAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := server.runIdempotent(ctx, authPayload.Username, "Deposit", req, func() (proto.Message, error) {
+		arg := db.DepositTxParams{
+			AccountID: req.GetAccountId(),
+			Amount:    req.GetAmount(),
+		}
+
+		result, err := server.store.DepositTx(ctx, arg)
+		if err != nil {
+			if errors.Is(err, db.ErrHouseAccount) {
+				return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to deposit money: %v", err)
+		}
+
+		return &pb.DepositResponse{
+			Account: convertAccount(result.Account),
+			Entry:   convertEntry(result.Entry),
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.(*pb.DepositResponse), nil
+}
+
+func validateDepositRequest(req *pb.DepositRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	return violations
+}
@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+	"simple-bank/pb"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Returns the limits that apply to the account once the account, currency and server defaults are merged
+func (server *Server) GetAccountTransferLimits(ctx context.Context, req *pb.GetAccountTransferLimitsRequest) (*pb.GetAccountTransferLimitsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetAccountTransferLimitsRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getViewableAccount(ctx, req.GetAccountId(), authPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := server.store.GetTransferLimits(ctx, account)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get transfer limits: %v", err)
+	}
+
+	rsp := &pb.GetAccountTransferLimitsResponse{
+		Limits: &pb.TransferLimits{
+			PerTransaction: limits.PerTransaction,
+			Daily:          limits.Daily,
+			Monthly:        limits.Monthly,
+		},
+	}
+
+	return rsp, nil
+}
+
+func validateGetAccountTransferLimitsRequest(req *pb.GetAccountTransferLimitsRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	return violations
+}
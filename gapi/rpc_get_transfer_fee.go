@@ -0,0 +1,51 @@
+package gapi
+
+import (
+	"context"
+	"simple-bank/pb"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Quotes the fee a transfer of the amount from the account would be charged, without making it
+func (server *Server) GetTransferFee(ctx context.Context, req *pb.GetTransferFeeRequest) (*pb.GetTransferFeeResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateGetTransferFeeRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.getViewableAccount(ctx, req.GetAccountId(), authPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := server.store.GetTransferFee(ctx, account, req.GetAmount())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get transfer fee: %v", err)
+	}
+
+	rsp := &pb.GetTransferFeeResponse{
+		Fee:      fee,
+		Currency: account.Currency,
+	}
+
+	return rsp, nil
+}
+
+func validateGetTransferFeeRequest(req *pb.GetTransferFeeRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	return violations
+}
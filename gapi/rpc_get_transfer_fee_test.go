@@ -0,0 +1,174 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGetTransferFeeAPI(t *testing.T) {
+	owner := util.RandomOwner()
+	account := randomAccount(owner)
+	amount := int64(1000)
+
+	testCases := []struct {
+		name          string
+		req           *pb.GetTransferFeeRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.GetTransferFeeResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.GetTransferFeeRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					GetTransferFee(gomock.Any(), gomock.Eq(account), gomock.Eq(amount)).
+					Times(1).
+					Return(int64(15), nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetTransferFeeResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(15), res.GetFee())
+				require.Equal(t, account.Currency, res.GetCurrency())
+			},
+		},
+		{
+			name: "BankerViewsAnyAccount",
+			req: &pb.GetTransferFeeRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetTransferFee(gomock.Any(), gomock.Eq(account), gomock.Eq(amount)).Times(1).Return(int64(0), nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, util.RandomOwner(), rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetTransferFeeResponse, err error) {
+				require.NoError(t, err)
+				require.Zero(t, res.GetFee())
+			},
+		},
+		{
+			name: "UnauthorizedUser",
+			req: &pb.GetTransferFeeRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetTransferFee(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, util.RandomOwner(), rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetTransferFeeResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name: "AccountNotFound",
+			req: &pb.GetTransferFeeRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().GetTransferFee(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetTransferFeeResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "InvalidAmount",
+			req: &pb.GetTransferFeeRequest{
+				AccountId: account.ID,
+				Amount:    0,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetTransferFee(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetTransferFeeResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "InternalError",
+			req: &pb.GetTransferFeeRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetTransferFee(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(int64(0), sql.ErrConnDone)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.GetTransferFeeResponse, err error) {
+				requireStatusCode(t, err, codes.Internal)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.GetTransferFeeRequest{
+				AccountId: account.ID,
+				Amount:    amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferFee(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.GetTransferFeeResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.GetTransferFee(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pagination"
+	"simple-bank/pb"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) ListAccountEntries(ctx context.Context, req *pb.ListAccountEntriesRequest) (*pb.ListAccountEntriesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAccountHistoryRequest(
+		req.GetAccountId(), req.GetPageSize(),
+		req.GetStartTime(), req.GetEndTime(), req.GetDirection(), req.MinAmount, req.MaxAmount,
+	)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.getViewableAccount(ctx, req.GetAccountId(), authPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := pagination.EntriesScope(req.GetAccountId())
+	afterID, err := server.pageTokenMaker.VerifyToken(req.GetPageToken(), scope)
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("page_token", err)})
+	}
+
+	arg := db.ListEntriesParams{
+		AccountID: req.GetAccountId(),
+		AfterID:   afterID,
+		StartTime: nullTime(req.GetStartTime()),
+		EndTime:   nullTime(req.GetEndTime()),
+		Direction: sql.NullString{String: req.GetDirection(), Valid: req.GetDirection() != ""},
+		MinAmount: nullInt64(req.MinAmount),
+		MaxAmount: nullInt64(req.MaxAmount),
+		Limit:     pagination.Limit(req.GetPageSize()),
+	}
+
+	entries, err := server.store.ListEntries(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list entries: %v", err)
+	}
+
+	entries, hasNextPage := pagination.Page(entries, req.GetPageSize())
+
+	rsp := &pb.ListAccountEntriesResponse{
+		Entries: make([]*pb.Entry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		rsp.Entries = append(rsp.Entries, convertEntry(entry))
+	}
+
+	if hasNextPage {
+		rsp.NextPageToken, err = server.pageTokenMaker.CreateToken(scope, entries[len(entries)-1].ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create page token: %v", err)
+		}
+	}
+
+	return rsp, nil
+}
+
+// Shared by the entry and transfer history of an account
+func validateAccountHistoryRequest(
+	accountID int64,
+	pageSize int32,
+	startTime *timestamppb.Timestamp,
+	endTime *timestamppb.Timestamp,
+	direction string,
+	minAmount *int64,
+	maxAmount *int64,
+) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(accountID); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidatePageSize(pageSize); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	if startTime != nil && endTime != nil && !startTime.AsTime().Before(endTime.AsTime()) {
+		violations = append(violations, fieldViolation("end_time", fmt.Errorf("must be after start_time")))
+	}
+	if direction != "" {
+		if err := val.ValidateDirection(direction); err != nil {
+			violations = append(violations, fieldViolation("direction", err))
+		}
+	}
+	if minAmount != nil && *minAmount < 0 {
+		violations = append(violations, fieldViolation("min_amount", fmt.Errorf("must not be negative")))
+	}
+	if minAmount != nil && maxAmount != nil && *minAmount > *maxAmount {
+		violations = append(violations, fieldViolation("max_amount", fmt.Errorf("must not be less than min_amount")))
+	}
+	return violations
+}
+
+func nullTime(value *timestamppb.Timestamp) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: value.AsTime(), Valid: true}
+}
+
+func nullInt64(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
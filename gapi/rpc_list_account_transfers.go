@@ -0,0 +1,83 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pagination"
+	"simple-bank/pb"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListAccountTransfers(ctx context.Context, req *pb.ListAccountTransfersRequest) (*pb.ListAccountTransfersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateAccountHistoryRequest(
+		req.GetAccountId(), req.GetPageSize(),
+		req.GetStartTime(), req.GetEndTime(), req.GetDirection(), req.MinAmount, req.MaxAmount,
+	)
+	if req.GetStatus() != "" {
+		if err := val.ValidateTransferStatus(req.GetStatus()); err != nil {
+			violations = append(violations, fieldViolation("status", err))
+		}
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	_, err = server.getViewableAccount(ctx, req.GetAccountId(), authPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := pagination.TransfersScope(req.GetAccountId())
+	afterID, err := server.pageTokenMaker.VerifyToken(req.GetPageToken(), scope)
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("page_token", err)})
+	}
+
+	arg := db.ListTransfersParams{
+		AccountID: req.GetAccountId(),
+		AfterID:   afterID,
+		StartTime: nullTime(req.GetStartTime()),
+		EndTime:   nullTime(req.GetEndTime()),
+		Direction: sql.NullString{String: req.GetDirection(), Valid: req.GetDirection() != ""},
+		MinAmount: nullInt64(req.MinAmount),
+		MaxAmount: nullInt64(req.MaxAmount),
+		Status: db.NullTransferStatus{
+			TransferStatus: db.TransferStatus(req.GetStatus()),
+			Valid:          req.GetStatus() != "",
+		},
+		Limit: pagination.Limit(req.GetPageSize()),
+	}
+
+	transfers, err := server.store.ListTransfers(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfers: %v", err)
+	}
+
+	transfers, hasNextPage := pagination.Page(transfers, req.GetPageSize())
+
+	rsp := &pb.ListAccountTransfersResponse{
+		Transfers: make([]*pb.Transfer, 0, len(transfers)),
+	}
+	for _, transfer := range transfers {
+		rsp.Transfers = append(rsp.Transfers, convertTransfer(transfer))
+	}
+
+	if hasNextPage {
+		rsp.NextPageToken, err = server.pageTokenMaker.CreateToken(scope, transfers[len(transfers)-1].ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create page token: %v", err)
+		}
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"context"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) ListCurrencies(ctx context.Context, req *pb.ListCurrenciesRequest) (*pb.ListCurrenciesResponse, error) {
+	_, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	currencies, err := server.store.ListCurrencies(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list currencies: %v", err)
+	}
+
+	rsp := &pb.ListCurrenciesResponse{
+		Currencies: make([]*pb.Currency, 0, len(currencies)),
+	}
+	for _, currency := range currencies {
+		rsp.Currencies = append(rsp.Currencies, convertCurrency(currency))
+	}
+
+	return rsp, nil
+}
+
+// Makes a currency change take effect in this process right away, other processes pick it up on their next refresh.
+// The change is already committed, so a failed reload is only logged.
+func (server *Server) reloadCurrencies(ctx context.Context) {
+	if err := db.LoadCurrencies(ctx, server.store); err != nil {
+		log.Error().Err(err).Msg("cannot reload currencies")
+	}
+}
+
+func convertCurrency(currency db.Currency) *pb.Currency {
+	return &pb.Currency{
+		Code:       currency.Code,
+		MinorUnits: currency.MinorUnits,
+		Enabled:    currency.Enabled,
+	}
+}
@@ -0,0 +1,185 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pagination"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+// An active scheduled transfer that runs every day
+func randomScheduledTransfer(owner string) db.ScheduledTransfer {
+	return db.ScheduledTransfer{
+		ID:              util.RandomInt(1, 1000),
+		Owner:           owner,
+		FromAccountID:   util.RandomInt(1, 1000),
+		ToAccountID:     util.RandomInt(1001, 2000),
+		Amount:          util.RandomMoney(),
+		Currency:        util.USD,
+		IntervalSeconds: sql.NullInt64{Int64: 24 * 60 * 60, Valid: true},
+		Status:          db.ScheduledTransferActive,
+		NextRunAt:       time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+	}
+}
+
+func TestListScheduledTransfersAPI(t *testing.T) {
+	owner := util.RandomOwner()
+	pageSize := int32(2)
+
+	scheduledTransfers := make([]db.ScheduledTransfer, pageSize+1)
+	for i := range scheduledTransfers {
+		scheduledTransfers[i] = randomScheduledTransfer(owner)
+		scheduledTransfers[i].ID = int64(i + 1)
+	}
+
+	testCases := []struct {
+		name          string
+		pageSize      int32
+		pageToken     func(t *testing.T, maker *pagination.Maker) string
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, maker *pagination.Maker, res *pb.ListScheduledTransfersResponse, err error)
+	}{
+		{
+			name:     "FirstPage",
+			pageSize: pageSize,
+			pageToken: func(t *testing.T, maker *pagination.Maker) string {
+				return ""
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListScheduledTransfersParams{
+					Owner:   owner,
+					AfterID: 0,
+					Limit:   pageSize + 1,
+				}
+				store.EXPECT().
+					ListScheduledTransfers(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(scheduledTransfers, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, maker *pagination.Maker, res *pb.ListScheduledTransfersResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetScheduledTransfers(), int(pageSize))
+
+				// The next page starts after the last scheduled transfer of this one
+				afterID, err := maker.VerifyToken(res.GetNextPageToken(), pagination.ScheduledTransfersScope(owner))
+				require.NoError(t, err)
+				require.Equal(t, scheduledTransfers[pageSize-1].ID, afterID)
+			},
+		},
+		{
+			name:     "LastPage",
+			pageSize: pageSize,
+			pageToken: func(t *testing.T, maker *pagination.Maker) string {
+				pageToken, err := maker.CreateToken(pagination.ScheduledTransfersScope(owner), scheduledTransfers[pageSize-1].ID)
+				require.NoError(t, err)
+				return pageToken
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListScheduledTransfersParams{
+					Owner:   owner,
+					AfterID: scheduledTransfers[pageSize-1].ID,
+					Limit:   pageSize + 1,
+				}
+				store.EXPECT().
+					ListScheduledTransfers(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(scheduledTransfers[pageSize:], nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, maker *pagination.Maker, res *pb.ListScheduledTransfersResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetScheduledTransfers(), 1)
+				require.Empty(t, res.GetNextPageToken())
+			},
+		},
+		{
+			// A page token of another user can't be used to page through this user's list
+			name:     "PageTokenOfAnotherUser",
+			pageSize: pageSize,
+			pageToken: func(t *testing.T, maker *pagination.Maker) string {
+				pageToken, err := maker.CreateToken(pagination.ScheduledTransfersScope(util.RandomOwner()), 1)
+				require.NoError(t, err)
+				return pageToken
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListScheduledTransfers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, maker *pagination.Maker, res *pb.ListScheduledTransfersResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name:     "InvalidPageSize",
+			pageSize: pagination.MaxPageSize + 1,
+			pageToken: func(t *testing.T, maker *pagination.Maker) string {
+				return ""
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListScheduledTransfers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, maker *pagination.Maker, res *pb.ListScheduledTransfersResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name:     "NoAuthorization",
+			pageSize: pageSize,
+			pageToken: func(t *testing.T, maker *pagination.Maker) string {
+				return ""
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListScheduledTransfers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, maker *pagination.Maker, res *pb.ListScheduledTransfersResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			req := &pb.ListScheduledTransfersRequest{
+				PageSize:  tc.pageSize,
+				PageToken: tc.pageToken(t, server.pageTokenMaker),
+			}
+			res, err := server.ListScheduledTransfers(ctx, req)
+			tc.checkResponse(t, server.pageTokenMaker, res, err)
+		})
+	}
+}
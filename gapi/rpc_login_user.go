@@ -7,6 +7,7 @@ import (
 	"simple-bank/pb"
 	"simple-bank/util"
 
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -26,8 +27,13 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 		return nil, status.Errorf(codes.Unauthenticated, "invalid credentials: %v", err)
 	}
 
+	// Both tokens are bound to the session created below
+	sessionID := uuid.New()
+
 	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
+		sessionID,
 		server.config.AccessTokenDuration,
 	)
 	if err != nil {
@@ -36,6 +42,8 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 
 	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(
 		user.Username,
+		user.Role,
+		sessionID,
 		server.config.RefreshTokenDuration,
 	)
 	if err != nil {
@@ -45,13 +53,15 @@ func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (
 	// Context object of the LoginUserrequest
 	mtdt := server.extractMetadata(ctx)
 	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
-		ID:           refreshPayload.ID,
+		ID:           sessionID,
 		Username:     user.Username,
 		RefreshToken: refreshToken,
 		UserAgent:    mtdt.UserAgent,
 		ClientIp:     mtdt.ClientIp,
 		IsBlocked:    false,
 		ExpiresAt:    refreshPayload.ExpiredAt,
+		// A login starts a new session family
+		FamilyID: sessionID,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create session: %v", err)
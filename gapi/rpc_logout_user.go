@@ -0,0 +1,60 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) LogoutUser(ctx context.Context, req *pb.LogoutUserRequest) (*pb.LogoutUserResponse, error) {
+	violations := validateLogoutUserRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.GetRefreshToken())
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	session, err := server.store.GetSession(ctx, refreshPayload.SessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "session not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find session: %v", err)
+	}
+
+	// Access tokens carry the same session id, only the refresh token itself is accepted
+	if session.Username != refreshPayload.Username || session.RefreshToken != req.GetRefreshToken() {
+		return nil, unauthenticatedError(errors.New("mismatched session token"))
+	}
+
+	_, err = server.store.BlockUserSession(ctx, db.BlockUserSessionParams{
+		ID:       refreshPayload.SessionID,
+		Username: refreshPayload.Username,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "session not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to block session: %v", err)
+	}
+	server.sessionCache.Invalidate(refreshPayload.SessionID)
+
+	return &pb.LogoutUserResponse{}, nil
+}
+
+func validateLogoutUserRequest(req *pb.LogoutUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateToken(req.GetRefreshToken()); err != nil {
+		violations = append(violations, fieldViolation("refresh_token", err))
+	}
+	return violations
+}
@@ -0,0 +1,163 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestLogoutUserAPI(t *testing.T) {
+	username := util.RandomOwner()
+	sessionID := uuid.New()
+
+	// The tokens are made before the server, so the stubs can return the session they belong to
+	tokenMaker, err := token.NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	refreshToken, _, err := tokenMaker.CreateToken(username, rbac.DepositorRole, sessionID, time.Minute)
+	require.NoError(t, err)
+	accessToken, _, err := tokenMaker.CreateToken(username, rbac.DepositorRole, sessionID, time.Minute)
+	require.NoError(t, err)
+	expiredToken, _, err := tokenMaker.CreateToken(username, rbac.DepositorRole, sessionID, -time.Minute)
+	require.NoError(t, err)
+
+	session := db.Session{
+		ID:           sessionID,
+		Username:     username,
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.LogoutUserRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.LogoutUserResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.LogoutUserRequest{
+				RefreshToken: refreshToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(sessionID)).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().
+					BlockUserSession(gomock.Any(), gomock.Eq(db.BlockUserSessionParams{
+						ID:       sessionID,
+						Username: username,
+					})).
+					Times(1).
+					Return(db.Session{ID: sessionID, Username: username, IsBlocked: true}, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+			},
+		},
+		{
+			// An access token carries the session id but can't end the session
+			name: "AccessToken",
+			req: &pb.LogoutUserRequest{
+				RefreshToken: accessToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(sessionID)).
+					Times(1).
+					Return(session, nil)
+				store.EXPECT().BlockUserSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "IncorrectSessionUser",
+			req: &pb.LogoutUserRequest{
+				RefreshToken: refreshToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				otherSession := session
+				otherSession.Username = util.RandomOwner()
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(sessionID)).
+					Times(1).
+					Return(otherSession, nil)
+				store.EXPECT().BlockUserSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "SessionNotFound",
+			req: &pb.LogoutUserRequest{
+				RefreshToken: refreshToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(sessionID)).
+					Times(1).
+					Return(db.Session{}, sql.ErrNoRows)
+				store.EXPECT().BlockUserSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "ExpiredToken",
+			req: &pb.LogoutUserRequest{
+				RefreshToken: expiredToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().BlockUserSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "MissingToken",
+			req:  &pb.LogoutUserRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().BlockUserSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LogoutUserResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			server.tokenMaker = tokenMaker
+			res, err := server.LogoutUser(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
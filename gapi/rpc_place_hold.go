@@ -0,0 +1,121 @@
+package gapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/val"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// How long a hold reserves funds when the request doesn't say
+const defaultHoldExpiry = 7 * 24 * time.Hour
+
+// The owner of the account authorizes a later transfer to the to account, which captures or releases it
+func (server *Server) PlaceHold(ctx context.Context, req *pb.PlaceHoldRequest) (*pb.PlaceHoldResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validatePlaceHoldRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.validAccount(ctx, req.GetAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Owner != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "account doesn't belong to the authenticated user")
+	}
+
+	// A capture is a transfer of the held amount, so there is no conversion
+	toAccount, err := server.validAccount(ctx, req.GetToAccountId(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+
+	err = validTransferTarget(toAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp, err := server.runIdempotent(ctx, authPayload.Username, "PlaceHold", req, func() (proto.Message, error) {
+		expiresIn := defaultHoldExpiry
+		if req.ExpiresInSeconds != nil {
+			expiresIn = time.Duration(req.GetExpiresInSeconds()) * time.Second
+		}
+
+		result, err := server.store.PlaceHoldTx(ctx, db.PlaceHoldTxParams{
+			AccountID:   req.GetAccountId(),
+			ToAccountID: req.GetToAccountId(),
+			Amount:      req.GetAmount(),
+			ExpiresAt:   time.Now().Add(expiresIn),
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrInsufficientFunds) {
+				return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to place hold: %v", err)
+		}
+
+		return &pb.PlaceHoldResponse{
+			Hold:    convertHold(result.Hold),
+			Account: convertAccount(result.Account),
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.(*pb.PlaceHoldResponse), nil
+}
+
+func convertHold(hold db.Hold) *pb.Hold {
+	return &pb.Hold{
+		Id:          hold.ID,
+		AccountId:   hold.AccountID,
+		ToAccountId: hold.ToAccountID,
+		Amount:      hold.Amount,
+		Fee:         hold.Fee,
+		Status:      hold.Status,
+		TransferId:  hold.TransferID.Int64,
+		ExpiresAt:   timestamppb.New(hold.ExpiresAt),
+		CreatedAt:   timestamppb.New(hold.CreatedAt),
+	}
+}
+
+func validatePlaceHoldRequest(req *pb.PlaceHoldRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetAccountId()); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	if err := val.ValidateAccountId(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	}
+	if req.GetAccountId() == req.GetToAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", fmt.Errorf("must be different from account_id")))
+	}
+	if err := val.ValidateAmount(req.GetAmount()); err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+	if req.ExpiresInSeconds != nil {
+		if err := val.ValidateHoldExpiresInSeconds(req.GetExpiresInSeconds()); err != nil {
+			violations = append(violations, fieldViolation("expires_in_seconds", err))
+		}
+	}
+	return violations
+}
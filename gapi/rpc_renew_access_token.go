@@ -0,0 +1,150 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"simple-bank/audit"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/val"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (server *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenRequest) (*pb.RenewAccessTokenResponse, error) {
+	violations := validateRenewAccessTokenRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.GetRefreshToken())
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	session, err := server.store.GetSession(ctx, refreshPayload.SessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "session not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find session: %v", err)
+	}
+
+	if session.Username != refreshPayload.Username {
+		return nil, unauthenticatedError(errors.New("incorrect session user"))
+	}
+
+	// Access tokens carry the same session id, so the token is checked before it can count as a reuse
+	if session.RefreshToken != req.GetRefreshToken() {
+		return nil, unauthenticatedError(errors.New("mismatched session token"))
+	}
+
+	// A replaced session means its refresh token was already rotated, so this one is a replay
+	if session.ReplacedBy.Valid {
+		return nil, server.revokeSessionFamily(ctx, session)
+	}
+
+	if session.IsBlocked {
+		return nil, unauthenticatedError(errors.New("blocked session"))
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return nil, unauthenticatedError(errors.New("expired session"))
+	}
+
+	// The role is read again so a role change applies from the next renewal
+	user, err := server.store.GetUser(ctx, session.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find user: %v", err)
+	}
+
+	// The renewed tokens belong to the session that replaces this one
+	newSessionID := uuid.New()
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
+		session.Username,
+		user.Role,
+		newSessionID,
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create access token: %v", err)
+	}
+
+	refreshToken, newRefreshPayload, err := server.tokenMaker.CreateToken(
+		session.Username,
+		user.Role,
+		newSessionID,
+		server.config.RefreshTokenDuration,
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create refresh token: %v", err)
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	newSession, err := server.store.RenewSessionTx(ctx, db.RenewSessionTxParams{
+		OldSessionID: session.ID,
+		NewSession: db.CreateSessionParams{
+			ID:           newSessionID,
+			Username:     session.Username,
+			RefreshToken: refreshToken,
+			UserAgent:    mtdt.UserAgent,
+			ClientIp:     mtdt.ClientIp,
+			IsBlocked:    false,
+			ExpiresAt:    newRefreshPayload.ExpiredAt,
+			FamilyID:     session.FamilyID,
+		},
+	})
+	if err != nil {
+		// Another request renewed the session first with the same refresh token
+		if errors.Is(err, db.ErrRefreshTokenReused) {
+			return nil, server.revokeSessionFamily(ctx, session)
+		}
+		if errors.Is(err, db.ErrSessionBlocked) {
+			return nil, unauthenticatedError(errors.New("blocked session"))
+		}
+		return nil, status.Errorf(codes.Internal, "failed to renew session: %v", err)
+	}
+
+	rsp := &pb.RenewAccessTokenResponse{
+		SessionId:             newSession.ID.String(),
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(newRefreshPayload.ExpiredAt),
+	}
+	return rsp, nil
+}
+
+// Blocks every session in the family of a session whose refresh token was reused
+// and returns the error for the request that presented it.
+func (server *Server) revokeSessionFamily(ctx context.Context, session db.Session) error {
+	err := server.store.BlockSessionFamily(ctx, session.FamilyID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to revoke session family: %v", err)
+	}
+	server.sessionCache.InvalidateUser(session.Username)
+
+	mtdt := server.extractMetadata(ctx)
+	audit.SecurityEvent(audit.EventRefreshTokenReuse, session.Username).
+		Str("session_id", session.ID.String()).
+		Str("family_id", session.FamilyID.String()).
+		Str("user_agent", mtdt.UserAgent).
+		Str("client_ip", mtdt.ClientIp).
+		Msg("refresh token reused, session family revoked")
+
+	return unauthenticatedError(errors.New("refresh token reused"))
+}
+
+func validateRenewAccessTokenRequest(req *pb.RenewAccessTokenRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateToken(req.GetRefreshToken()); err != nil {
+		violations = append(violations, fieldViolation("refresh_token", err))
+	}
+	return violations
+}
@@ -0,0 +1,268 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRenewAccessTokenAPI(t *testing.T) {
+	username := util.RandomOwner()
+	sessionID := uuid.New()
+	familyID := uuid.New()
+
+	// The tokens are made before the server, so the stubs can return the session they belong to
+	tokenMaker, err := token.NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	refreshToken, _, err := tokenMaker.CreateToken(username, rbac.DepositorRole, sessionID, time.Minute)
+	require.NoError(t, err)
+	accessToken, _, err := tokenMaker.CreateToken(username, rbac.DepositorRole, sessionID, time.Minute)
+	require.NoError(t, err)
+	expiredToken, _, err := tokenMaker.CreateToken(username, rbac.DepositorRole, sessionID, -time.Minute)
+	require.NoError(t, err)
+
+	session := db.Session{
+		ID:           sessionID,
+		Username:     username,
+		RefreshToken: refreshToken,
+		FamilyID:     familyID,
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+
+	rotatedSession := session
+	rotatedSession.ReplacedBy = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+
+	blockedSession := session
+	blockedSession.IsBlocked = true
+
+	// The token can outlive its session, for example after the session lifetime was shortened
+	expiredSession := session
+	expiredSession.ExpiresAt = time.Now().Add(-time.Minute)
+
+	otherUsersSession := session
+	otherUsersSession.Username = util.RandomOwner()
+
+	testCases := []struct {
+		name          string
+		req           *pb.RenewAccessTokenRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.RenewAccessTokenResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(sessionID)).Times(1).Return(session, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(username)).
+					Times(1).
+					Return(db.User{Username: username, Role: rbac.BankerRole}, nil)
+				store.EXPECT().
+					RenewSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.RenewSessionTxParams) (db.Session, error) {
+						require.Equal(t, sessionID, arg.OldSessionID)
+						require.NotEqual(t, sessionID, arg.NewSession.ID)
+						require.Equal(t, familyID, arg.NewSession.FamilyID)
+						require.Equal(t, username, arg.NewSession.Username)
+						require.NotEqual(t, refreshToken, arg.NewSession.RefreshToken)
+
+						return db.Session{ID: arg.NewSession.ID, Username: username, FamilyID: familyID}, nil
+					})
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RenewAccessTokenResponse, err error) {
+				require.NoError(t, err)
+				require.NotEqual(t, sessionID.String(), res.GetSessionId())
+				require.NotEmpty(t, res.GetAccessToken())
+				require.NotEqual(t, refreshToken, res.GetRefreshToken())
+
+				// The renewed tokens carry the current role and the new session
+				payload, err := tokenMaker.VerifyToken(res.GetAccessToken())
+				require.NoError(t, err)
+				require.Equal(t, rbac.BankerRole, payload.Role)
+				require.Equal(t, res.GetSessionId(), payload.SessionID.String())
+			},
+		},
+		{
+			name: "BlockedSession",
+			req: &pb.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(sessionID)).Times(1).Return(blockedSession, nil)
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "ExpiredSession",
+			req: &pb.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(sessionID)).Times(1).Return(expiredSession, nil)
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "ExpiredToken",
+			req: &pb.RenewAccessTokenRequest{
+				RefreshToken: expiredToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "IncorrectSessionUser",
+			req: &pb.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(sessionID)).Times(1).Return(otherUsersSession, nil)
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			// An access token carries the session id, but presenting it isn't a refresh token reuse
+			name: "MismatchedToken",
+			req: &pb.RenewAccessTokenRequest{
+				RefreshToken: accessToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(sessionID)).Times(1).Return(rotatedSession, nil)
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "ReusedRefreshToken",
+			req: &pb.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(sessionID)).Times(1).Return(rotatedSession, nil)
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Eq(familyID)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			// Another request rotated the refresh token between the read and the renewal
+			name: "ConcurrentReuse",
+			req: &pb.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(sessionID)).Times(1).Return(session, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(username)).
+					Times(1).
+					Return(db.User{Username: username, Role: rbac.DepositorRole}, nil)
+				store.EXPECT().
+					RenewSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{}, db.ErrRefreshTokenReused)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Eq(familyID)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "BlockedDuringRenewal",
+			req: &pb.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(sessionID)).Times(1).Return(session, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(username)).
+					Times(1).
+					Return(db.User{Username: username, Role: rbac.DepositorRole}, nil)
+				store.EXPECT().
+					RenewSessionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Session{}, db.ErrSessionBlocked)
+				store.EXPECT().BlockSessionFamily(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+		{
+			name: "SessionNotFound",
+			req: &pb.RenewAccessTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(sessionID)).Times(1).Return(db.Session{}, sql.ErrNoRows)
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "MissingToken",
+			req:  &pb.RenewAccessTokenRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RenewSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.RenewAccessTokenResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			server.tokenMaker = tokenMaker
+			res, err := server.RenewAccessToken(context.Background(), tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Bankers fix mistaken transfers with a compensating transfer instead of editing balances
+func (server *Server) ReverseTransfer(ctx context.Context, req *pb.ReverseTransferRequest) (*pb.ReverseTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := rbac.Authorize(authPayload.Role, rbac.ReverseTransfers); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "only bankers can reverse transfers: %v", err)
+	}
+
+	violations := validateReverseTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	rsp, err := server.runIdempotent(ctx, authPayload.Username, "ReverseTransfer", req, func() (proto.Message, error) {
+		result, err := server.store.ReverseTransferTx(ctx, db.ReverseTransferTxParams{
+			TransferID: req.GetTransferId(),
+			Amount:     req.GetAmount(),
+		})
+		if err != nil {
+			switch {
+			case err == sql.ErrNoRows:
+				return nil, status.Errorf(codes.NotFound, "transfer not found: %v", err)
+			case errors.Is(err, db.ErrRefundExceedsTransfer), errors.Is(err, db.ErrRefundTooSmall):
+				return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+			case errors.Is(err, db.ErrReversalOfReversal),
+				errors.Is(err, db.ErrAlreadyReversed),
+				errors.Is(err, db.ErrTransferNotCompleted),
+				errors.Is(err, db.ErrInsufficientFunds):
+				return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+			}
+			return nil, status.Errorf(codes.Internal, "failed to reverse transfer: %v", err)
+		}
+
+		return &pb.ReverseTransferResponse{
+			Transfer:        convertTransfer(result.Reversal.Transfer),
+			FromAccount:     convertAccount(result.Reversal.FromAccount),
+			ToAccount:       convertAccount(result.Reversal.ToAccount),
+			FromEntry:       convertEntry(result.Reversal.FromEntry),
+			ToEntry:         convertEntry(result.Reversal.ToEntry),
+			RemainingAmount: result.RemainingAmount,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.(*pb.ReverseTransferResponse), nil
+}
+
+func validateReverseTransferRequest(req *pb.ReverseTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateId(req.GetTransferId()); err != nil {
+		violations = append(violations, fieldViolation("transfer_id", err))
+	}
+	if req.Amount != nil {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+	return violations
+}
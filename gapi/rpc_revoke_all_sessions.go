@@ -0,0 +1,27 @@
+package gapi
+
+import (
+	"context"
+	"simple-bank/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	revoked, err := server.store.BlockUserSessions(ctx, authPayload.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke sessions: %v", err)
+	}
+	server.sessionCache.InvalidateUser(authPayload.Username)
+
+	rsp := &pb.RevokeAllSessionsResponse{
+		RevokedSessions: revoked,
+	}
+	return rsp, nil
+}
@@ -0,0 +1,107 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Replaces the limits of the currency, or of the account when one is given.
+// A limit left out of the request falls back to the currency or the defaults of the server.
+func (server *Server) SetTransferLimit(ctx context.Context, req *pb.SetTransferLimitRequest) (*pb.SetTransferLimitResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := rbac.Authorize(authPayload.Role, rbac.ManageLimits); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "only bankers can set transfer limits: %v", err)
+	}
+
+	violations := validateSetTransferLimitRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	if req.AccountId != nil {
+		_, err = server.validAccount(ctx, req.GetAccountId(), req.GetCurrency())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limit, err := server.store.UpsertLimit(ctx, db.UpsertLimitParams{
+		Currency:       req.GetCurrency(),
+		AccountID:      nullInt64(req.AccountId),
+		PerTransaction: nullInt64(req.PerTransaction),
+		Daily:          nullInt64(req.Daily),
+		Monthly:        nullInt64(req.Monthly),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to set transfer limit: %v", err)
+	}
+
+	rsp := &pb.SetTransferLimitResponse{
+		Limit: convertTransferLimit(limit),
+	}
+
+	return rsp, nil
+}
+
+func optionalInt64(value sql.NullInt64) *int64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int64
+}
+
+func convertTransferLimit(limit db.Limit) *pb.TransferLimit {
+	return &pb.TransferLimit{
+		Currency:       limit.Currency,
+		AccountId:      optionalInt64(limit.AccountID),
+		PerTransaction: optionalInt64(limit.PerTransaction),
+		Daily:          optionalInt64(limit.Daily),
+		Monthly:        optionalInt64(limit.Monthly),
+		UpdatedAt:      timestamppb.New(limit.UpdatedAt),
+	}
+}
+
+func validateSetTransferLimitRequest(req *pb.SetTransferLimitRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrency(req.GetCurrency()); err != nil {
+		violations = append(violations, fieldViolation("currency", err))
+	}
+
+	if req.AccountId != nil {
+		if err := val.ValidateAccountId(req.GetAccountId()); err != nil {
+			violations = append(violations, fieldViolation("account_id", err))
+		}
+	}
+
+	if req.PerTransaction != nil {
+		if err := val.ValidateTransferLimit(req.GetPerTransaction()); err != nil {
+			violations = append(violations, fieldViolation("per_transaction", err))
+		}
+	}
+
+	if req.Daily != nil {
+		if err := val.ValidateTransferLimit(req.GetDaily()); err != nil {
+			violations = append(violations, fieldViolation("daily", err))
+		}
+	}
+
+	if req.Monthly != nil {
+		if err := val.ValidateTransferLimit(req.GetMonthly()); err != nil {
+			violations = append(violations, fieldViolation("monthly", err))
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,211 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSetTransferLimitAPI(t *testing.T) {
+	banker := util.RandomOwner()
+	depositor := util.RandomOwner()
+	account := randomAccount(depositor)
+
+	testCases := []struct {
+		name          string
+		req           *pb.SetTransferLimitRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.SetTransferLimitResponse, err error)
+	}{
+		{
+			name: "CurrencyLimit",
+			req: &pb.SetTransferLimitRequest{
+				Currency: util.USD,
+				Daily:    proto.Int64(500),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertLimitParams{
+					Currency: util.USD,
+					Daily:    sql.NullInt64{Int64: 500, Valid: true},
+				}
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					UpsertLimit(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Limit{Currency: arg.Currency, Daily: arg.Daily}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitResponse, err error) {
+				require.NoError(t, err)
+				require.Nil(t, res.GetLimit().AccountId)
+				require.Equal(t, int64(500), res.GetLimit().GetDaily())
+				require.Nil(t, res.GetLimit().PerTransaction)
+			},
+		},
+		{
+			// 0 turns the limit off for the account
+			name: "AccountLimitOff",
+			req: &pb.SetTransferLimitRequest{
+				Currency:  util.USD,
+				AccountId: proto.Int64(account.ID),
+				Monthly:   proto.Int64(0),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertLimitParams{
+					Currency:  util.USD,
+					AccountID: sql.NullInt64{Int64: account.ID, Valid: true},
+					Monthly:   sql.NullInt64{Int64: 0, Valid: true},
+				}
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					UpsertLimit(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.Limit{Currency: arg.Currency, AccountID: arg.AccountID, Monthly: arg.Monthly}, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, account.ID, res.GetLimit().GetAccountId())
+				require.NotNil(t, res.GetLimit().Monthly)
+				require.Zero(t, res.GetLimit().GetMonthly())
+			},
+		},
+		{
+			name: "Depositor",
+			req: &pb.SetTransferLimitRequest{
+				Currency:  util.USD,
+				AccountId: proto.Int64(account.ID),
+				Daily:     proto.Int64(0),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				// Owning the account doesn't let its owner lift its limits
+				return newContextWithBearerToken(t, tokenMaker, depositor, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name: "NegativeLimit",
+			req: &pb.SetTransferLimitRequest{
+				Currency:       util.USD,
+				PerTransaction: proto.Int64(-1),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "InvalidAccountId",
+			req: &pb.SetTransferLimitRequest{
+				Currency:  util.USD,
+				AccountId: proto.Int64(0),
+				Daily:     proto.Int64(100),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "AccountCurrencyMismatch",
+			req: &pb.SetTransferLimitRequest{
+				Currency:  util.EUR,
+				AccountId: proto.Int64(account.ID),
+				Daily:     proto.Int64(100),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpsertLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "AccountNotFound",
+			req: &pb.SetTransferLimitRequest{
+				Currency:  util.USD,
+				AccountId: proto.Int64(account.ID),
+				Daily:     proto.Int64(100),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(db.Account{}, sql.ErrNoRows)
+				store.EXPECT().UpsertLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.SetTransferLimitRequest{
+				Currency: util.USD,
+				Daily:    proto.Int64(100),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.SetTransferLimitResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.SetTransferLimit(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Lowering the limit doesn't touch the balance, an account already below it just can't move money out
+func (server *Server) UpdateAccountOverdraftLimit(ctx context.Context, req *pb.UpdateAccountOverdraftLimitRequest) (*pb.UpdateAccountOverdraftLimitResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := rbac.Authorize(authPayload.Role, rbac.ManageOverdrafts); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "only bankers can update overdraft limits: %v", err)
+	}
+
+	violations := validateUpdateAccountOverdraftLimitRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.store.UpdateAccountOverdraftLimit(ctx, db.UpdateAccountOverdraftLimitParams{
+		ID:             req.GetId(),
+		OverdraftLimit: req.GetOverdraftLimit(),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "account not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update overdraft limit: %v", err)
+	}
+
+	rsp := &pb.UpdateAccountOverdraftLimitResponse{
+		Account: convertAccount(account),
+	}
+
+	return rsp, nil
+}
+
+func validateUpdateAccountOverdraftLimitRequest(req *pb.UpdateAccountOverdraftLimitRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateAccountId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if err := val.ValidateOverdraftLimit(req.GetOverdraftLimit()); err != nil {
+		violations = append(violations, fieldViolation("overdraft_limit", err))
+	}
+	return violations
+}
@@ -0,0 +1,142 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestUpdateAccountOverdraftLimitAPI(t *testing.T) {
+	account := randomAccount(util.RandomOwner())
+
+	testCases := []struct {
+		name          string
+		req           *pb.UpdateAccountOverdraftLimitRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.UpdateAccountOverdraftLimitResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.UpdateAccountOverdraftLimitRequest{
+				Id:             account.ID,
+				OverdraftLimit: 500,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				updated := account
+				updated.OverdraftLimit = 500
+
+				store.EXPECT().
+					UpdateAccountOverdraftLimit(gomock.Any(), gomock.Eq(db.UpdateAccountOverdraftLimitParams{
+						ID:             account.ID,
+						OverdraftLimit: 500,
+					})).
+					Times(1).
+					Return(updated, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateAccountOverdraftLimitResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(500), res.GetAccount().GetOverdraftLimit())
+			},
+		},
+		{
+			name: "Depositor",
+			req: &pb.UpdateAccountOverdraftLimitRequest{
+				Id:             account.ID,
+				OverdraftLimit: 500,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccountOverdraftLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, account.Owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateAccountOverdraftLimitResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name: "NegativeLimit",
+			req: &pb.UpdateAccountOverdraftLimitRequest{
+				Id:             account.ID,
+				OverdraftLimit: -1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccountOverdraftLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateAccountOverdraftLimitResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.UpdateAccountOverdraftLimitRequest{
+				Id:             account.ID,
+				OverdraftLimit: 500,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateAccountOverdraftLimit(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Account{}, sql.ErrNoRows)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateAccountOverdraftLimitResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.UpdateAccountOverdraftLimitRequest{
+				Id:             account.ID,
+				OverdraftLimit: 500,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateAccountOverdraftLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateAccountOverdraftLimitResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+
+			res, err := server.UpdateAccountOverdraftLimit(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
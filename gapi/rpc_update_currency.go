@@ -0,0 +1,57 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/val"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Disabling a currency keeps its accounts, but they can't be used for transfers until it is enabled again
+func (server *Server) UpdateCurrency(ctx context.Context, req *pb.UpdateCurrencyRequest) (*pb.UpdateCurrencyResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	if err := rbac.Authorize(authPayload.Role, rbac.ManageCurrencies); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "only bankers can update currencies: %v", err)
+	}
+
+	violations := validateUpdateCurrencyRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	currency, err := server.store.UpdateCurrency(ctx, db.UpdateCurrencyParams{
+		Code:    req.GetCode(),
+		Enabled: req.GetEnabled(),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "currency not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update currency: %v", err)
+	}
+
+	server.reloadCurrencies(ctx)
+
+	rsp := &pb.UpdateCurrencyResponse{
+		Currency: convertCurrency(currency),
+	}
+
+	return rsp, nil
+}
+
+func validateUpdateCurrencyRequest(req *pb.UpdateCurrencyRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateCurrencyCode(req.GetCode()); err != nil {
+		violations = append(violations, fieldViolation("code", err))
+	}
+	return violations
+}
@@ -0,0 +1,154 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"simple-bank/val"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestUpdateCurrencyAPI(t *testing.T) {
+	banker := util.RandomOwner()
+	depositor := util.RandomOwner()
+	disabled := db.Currency{
+		Code:       util.EUR,
+		MinorUnits: 2,
+		Enabled:    false,
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.UpdateCurrencyRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.UpdateCurrencyResponse, err error)
+	}{
+		{
+			// Accounts in the currency are kept, only new money movements in it are rejected
+			name: "DisableCurrencyInUse",
+			req: &pb.UpdateCurrencyRequest{
+				Code:    disabled.Code,
+				Enabled: false,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateCurrencyParams{
+					Code:    disabled.Code,
+					Enabled: false,
+				}
+				store.EXPECT().
+					UpdateCurrency(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(disabled, nil)
+				stubReloadCurrencies(store, disabled)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateCurrencyResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, res.GetCurrency().GetEnabled())
+				require.Error(t, val.ValidateCurrency(disabled.Code))
+				require.NoError(t, val.ValidateCurrency(util.USD))
+			},
+		},
+		{
+			name: "Depositor",
+			req: &pb.UpdateCurrencyRequest{
+				Code:    disabled.Code,
+				Enabled: false,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCurrency(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListCurrencies(gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, depositor, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateCurrencyResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+				require.NoError(t, val.ValidateCurrency(disabled.Code))
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.UpdateCurrencyRequest{
+				Code:    "GBP",
+				Enabled: true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateCurrency(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Currency{}, sql.ErrNoRows)
+				store.EXPECT().ListCurrencies(gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateCurrencyResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "InvalidCode",
+			req: &pb.UpdateCurrencyRequest{
+				Code: "EURO",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, banker, rbac.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateCurrencyResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.UpdateCurrencyRequest{
+				Code: disabled.Code,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCurrency(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateCurrencyResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// The handlers reload the currency registry, which the other tests rely on
+			t.Cleanup(restoreCurrencies)
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.UpdateCurrency(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
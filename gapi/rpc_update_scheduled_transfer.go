@@ -0,0 +1,90 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/val"
+	"simple-bank/worker"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (server *Server) UpdateScheduledTransfer(ctx context.Context, req *pb.UpdateScheduledTransferRequest) (*pb.UpdateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, unauthenticatedError(err)
+	}
+
+	violations := validateUpdateScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.getOwnedScheduledTransfer(ctx, req.GetId(), authPayload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheduledTransfer.Status != db.ScheduledTransferActive && scheduledTransfer.Status != db.ScheduledTransferPaused {
+		return nil, status.Errorf(codes.FailedPrecondition, "scheduled transfer is %s", scheduledTransfer.Status)
+	}
+
+	arg := db.UpdateScheduledTransferParams{
+		ID: scheduledTransfer.ID,
+		Amount: sql.NullInt64{
+			Int64: req.GetAmount(),
+			Valid: req.Amount != nil,
+		},
+	}
+
+	if req.Paused != nil {
+		if req.GetPaused() {
+			arg.Status = sql.NullString{String: db.ScheduledTransferPaused, Valid: true}
+		} else {
+			arg.Status = sql.NullString{String: db.ScheduledTransferActive, Valid: true}
+
+			// Occurrences missed while paused are skipped, a one-off transfer runs right away
+			if scheduledTransfer.Status == db.ScheduledTransferPaused && scheduledTransfer.NextRunAt.Before(time.Now()) {
+				nextRunAt, ok, err := worker.NextScheduledRun(scheduledTransfer, time.Now())
+				if err != nil {
+					return nil, status.Errorf(codes.Internal, "failed to schedule next run: %v", err)
+				}
+				if ok {
+					arg.NextRunAt = sql.NullTime{Time: nextRunAt, Valid: true}
+				}
+			}
+		}
+	}
+
+	scheduledTransfer, err = server.store.UpdateScheduledTransfer(ctx, arg)
+	if err != nil {
+		// The scheduler completed it or it was cancelled after it was read
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.FailedPrecondition, "scheduled transfer is no longer active or paused")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update scheduled transfer: %v", err)
+	}
+
+	rsp := &pb.UpdateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+
+	return rsp, nil
+}
+
+func validateUpdateScheduledTransferRequest(req *pb.UpdateScheduledTransferRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := val.ValidateId(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if req.Amount != nil {
+		if err := val.ValidateAmount(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+	return violations
+}
@@ -0,0 +1,298 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/pb"
+	"simple-bank/rbac"
+	"simple-bank/token"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUpdateScheduledTransferAPI(t *testing.T) {
+	owner := util.RandomOwner()
+	other := util.RandomOwner()
+
+	scheduledTransfer := randomScheduledTransfer(owner)
+
+	// Paused with an hourly interval and an occurrence that was missed 90 minutes ago
+	pastDue := scheduledTransfer
+	pastDue.Status = db.ScheduledTransferPaused
+	pastDue.IntervalSeconds = sql.NullInt64{Int64: 60 * 60, Valid: true}
+	pastDue.NextRunAt = time.Now().Add(-90 * time.Minute).UTC().Truncate(time.Second)
+
+	oneOffPastDue := pastDue
+	oneOffPastDue.IntervalSeconds = sql.NullInt64{}
+
+	pausedNotDue := scheduledTransfer
+	pausedNotDue.Status = db.ScheduledTransferPaused
+
+	completed := scheduledTransfer
+	completed.Status = db.ScheduledTransferCompleted
+
+	testCases := []struct {
+		name          string
+		req           *pb.UpdateScheduledTransferRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error)
+	}{
+		{
+			name: "Amount",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Amount: proto.Int64(scheduledTransfer.Amount + 1),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduledTransfer.ID)).Times(1).Return(scheduledTransfer, nil)
+
+				arg := db.UpdateScheduledTransferParams{
+					ID:     scheduledTransfer.ID,
+					Amount: sql.NullInt64{Int64: scheduledTransfer.Amount + 1, Valid: true},
+				}
+				updated := scheduledTransfer
+				updated.Amount++
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, scheduledTransfer.Amount+1, res.GetScheduledTransfer().GetAmount())
+			},
+		},
+		{
+			name: "Pause",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Paused: proto.Bool(true),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduledTransfer.ID)).Times(1).Return(scheduledTransfer, nil)
+
+				arg := db.UpdateScheduledTransferParams{
+					ID:     scheduledTransfer.ID,
+					Status: sql.NullString{String: db.ScheduledTransferPaused, Valid: true},
+				}
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(pausedNotDue, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.ScheduledTransferPaused, res.GetScheduledTransfer().GetStatus())
+			},
+		},
+		{
+			// The occurrences missed while paused are skipped
+			name: "ResumePastDue",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     pastDue.ID,
+				Paused: proto.Bool(false),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(pastDue.ID)).Times(1).Return(pastDue, nil)
+				store.EXPECT().
+					UpdateScheduledTransfer(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateScheduledTransferParams) (db.ScheduledTransfer, error) {
+						require.Equal(t, sql.NullString{String: db.ScheduledTransferActive, Valid: true}, arg.Status)
+						require.True(t, arg.NextRunAt.Valid)
+						require.True(t, pastDue.NextRunAt.Add(2*time.Hour).Equal(arg.NextRunAt.Time))
+
+						resumed := pastDue
+						resumed.Status = db.ScheduledTransferActive
+						resumed.NextRunAt = arg.NextRunAt.Time
+						return resumed, nil
+					})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.ScheduledTransferActive, res.GetScheduledTransfer().GetStatus())
+				require.True(t, res.GetScheduledTransfer().GetNextRunAt().AsTime().After(time.Now()))
+			},
+		},
+		{
+			// A one-off transfer keeps its past run time, so it runs right away
+			name: "ResumeOneOffPastDue",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     oneOffPastDue.ID,
+				Paused: proto.Bool(false),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(oneOffPastDue.ID)).Times(1).Return(oneOffPastDue, nil)
+
+				arg := db.UpdateScheduledTransferParams{
+					ID:     oneOffPastDue.ID,
+					Status: sql.NullString{String: db.ScheduledTransferActive, Valid: true},
+				}
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(oneOffPastDue, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "ResumeNotDue",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     pausedNotDue.ID,
+				Paused: proto.Bool(false),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(pausedNotDue.ID)).Times(1).Return(pausedNotDue, nil)
+
+				arg := db.UpdateScheduledTransferParams{
+					ID:     pausedNotDue.ID,
+					Status: sql.NullString{String: db.ScheduledTransferActive, Valid: true},
+				}
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(scheduledTransfer, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "NotOwner",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Paused: proto.Bool(true),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduledTransfer.ID)).Times(1).Return(scheduledTransfer, nil)
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, other, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+		{
+			name: "NotFound",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Paused: proto.Bool(true),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduledTransfer.ID)).Times(1).Return(db.ScheduledTransfer{}, sql.ErrNoRows)
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "Completed",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     completed.ID,
+				Paused: proto.Bool(false),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(completed.ID)).Times(1).Return(completed, nil)
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			// The scheduler completed it between the read and the update
+			name: "NoLongerActive",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Paused: proto.Bool(true),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduledTransfer.ID)).Times(1).Return(scheduledTransfer, nil)
+				store.EXPECT().
+					UpdateScheduledTransfer(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ScheduledTransfer{}, sql.ErrNoRows)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "InvalidAmount",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Amount: proto.Int64(0),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, owner, rbac.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Paused: proto.Bool(true),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return context.Background()
+			},
+			checkResponse: func(t *testing.T, res *pb.UpdateScheduledTransferResponse, err error) {
+				requireStatusCode(t, err, codes.Unauthenticated)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store, nil)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			res, err := server.UpdateScheduledTransfer(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
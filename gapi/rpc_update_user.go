@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	db "simple-bank/db/sqlc"
 	"simple-bank/pb"
+	"simple-bank/rbac"
 	"simple-bank/util"
 	"simple-bank/val"
+	"simple-bank/worker"
 	"time"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -25,7 +27,7 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, invalidArgumentError(violations)
 	}
 
-	if authPayload.Username != req.GetUsername() {
+	if authPayload.Username != req.GetUsername() && !rbac.Can(authPayload.Role, rbac.UpdateAnyUser) {
 		return nil, status.Errorf(codes.PermissionDenied, "cannot update other user's info")
 	}
 
@@ -46,7 +48,20 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		arg.PasswordChangedAt = sql.NullTime{Time: time.Now(), Valid: true}
 	}
 
-	user, err := server.store.UpdateUser(ctx, arg)
+	txResult, err := server.store.UpdateUserTx(ctx, db.UpdateUserTxParams{
+		UpdateUserParams: arg,
+		// A new email address is unverified until the user confirms it, so the task is queued with the change
+		AfterUpdate: func(q db.Querier, oldUser db.User, user db.User) error {
+			if user.Email == oldUser.Email {
+				return nil
+			}
+
+			taskPayload := &worker.PayloadSendVerifyEmail{
+				Username: user.Username,
+			}
+			return server.taskDistributor.DistributeTaskSendVerifyEmail(ctx, q, taskPayload)
+		},
+	})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
@@ -54,6 +69,7 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
 	}
 
+	user := txResult.User
 	rsp := &pb.UpdateUserResponse{
 		User: &pb.User{
 			Username: user.Username,
@@ -3,9 +3,13 @@ package gapi
 import (
 	"fmt"
 	db "simple-bank/db/sqlc"
+	"simple-bank/fx"
+	"simple-bank/pagination"
 	"simple-bank/pb"
+	"simple-bank/sessioncache"
 	"simple-bank/token"
 	"simple-bank/util"
+	"simple-bank/worker"
 )
 
 type Server struct {
@@ -14,22 +18,41 @@ type Server struct {
 	// If someone adds a new RPC method to your `.proto` file,
 	// Go code will still compile because `pb.UnimplementedSimpleBankServer` provides a default implementation for "new method".
 	pb.UnimplementedSimpleBankServer
-	config     util.Config
-	store      db.Store
-	tokenMaker token.Maker
+	config         util.Config
+	store          db.Store
+	tokenMaker     token.Maker
+	pageTokenMaker *pagination.Maker
+	sessionCache   *sessioncache.Cache
+	// Enqueues the side effects of a request to run in the background
+	taskDistributor worker.TaskDistributor
+	rateProvider    fx.RateProvider
 }
 
 // Creates a new gRPC server and setup routing
-func NewServer(config util.Config, store db.Store) (*Server, error) {
+func NewServer(config util.Config, store db.Store, taskDistributor worker.TaskDistributor) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	pageTokenMaker, err := pagination.NewMaker(config.TokenSymmetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create page token maker: %w", err)
+	}
+
+	rateProvider, err := fx.NewProvider(config, store)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create rate provider: %w", err)
+	}
+
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:          config,
+		store:           store,
+		tokenMaker:      tokenMaker,
+		pageTokenMaker:  pageTokenMaker,
+		sessionCache:    sessioncache.New(store, config.SessionCacheTTL),
+		taskDistributor: taskDistributor,
+		rateProvider:    rateProvider,
 	}
 
 	return server, nil
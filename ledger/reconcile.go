@@ -0,0 +1,98 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	db "simple-bank/db/sqlc"
+	"time"
+)
+
+// Result of a reconciliation run, an empty list means the invariant holds
+type Report struct {
+	CheckedAt  time.Time             `json:"checked_at"`
+	OK         bool                  `json:"ok"`
+	Accounts   []AccountDiscrepancy  `json:"accounts"`
+	Transfers  []TransferDiscrepancy `json:"transfers"`
+	Currencies []CurrencyDiscrepancy `json:"currencies"`
+}
+
+// An account whose balance is not the sum of its entries
+type AccountDiscrepancy struct {
+	AccountID    int64  `json:"account_id"`
+	Owner        string `json:"owner"`
+	Currency     string `json:"currency"`
+	Balance      int64  `json:"balance"`
+	EntriesTotal int64  `json:"entries_total"`
+	Difference   int64  `json:"difference"`
+}
+
+// A transfer without exactly one debit entry on the from account and one credit entry on the to account
+type TransferDiscrepancy struct {
+	TransferID    int64 `json:"transfer_id"`
+	FromAccountID int64 `json:"from_account_id"`
+	ToAccountID   int64 `json:"to_account_id"`
+	Amount        int64 `json:"amount"`
+	EntryCount    int64 `json:"entry_count"`
+	EntriesTotal  int64 `json:"entries_total"`
+}
+
+// A currency whose entries don't net to zero
+type CurrencyDiscrepancy struct {
+	Currency     string `json:"currency"`
+	EntriesTotal int64  `json:"entries_total"`
+}
+
+// Checks the double-entry invariants of the ledger.
+// Each check is a single statement, so it sees a consistent snapshot even while transfers are running.
+func Reconcile(ctx context.Context, q db.Querier) (*Report, error) {
+	report := &Report{
+		CheckedAt:  time.Now().UTC(),
+		Accounts:   []AccountDiscrepancy{},
+		Transfers:  []TransferDiscrepancy{},
+		Currencies: []CurrencyDiscrepancy{},
+	}
+
+	accounts, err := q.ListBalanceMismatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check account balances: %w", err)
+	}
+	for _, account := range accounts {
+		report.Accounts = append(report.Accounts, AccountDiscrepancy{
+			AccountID:    account.ID,
+			Owner:        account.Owner,
+			Currency:     account.Currency,
+			Balance:      account.Balance,
+			EntriesTotal: account.EntriesTotal,
+			Difference:   account.Balance - account.EntriesTotal,
+		})
+	}
+
+	transfers, err := q.ListUnbalancedTransfers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check transfer entries: %w", err)
+	}
+	for _, transfer := range transfers {
+		report.Transfers = append(report.Transfers, TransferDiscrepancy{
+			TransferID:    transfer.ID,
+			FromAccountID: transfer.FromAccountID,
+			ToAccountID:   transfer.ToAccountID,
+			Amount:        transfer.Amount,
+			EntryCount:    transfer.EntryCount,
+			EntriesTotal:  transfer.EntriesTotal,
+		})
+	}
+
+	currencies, err := q.ListCurrencyImbalances(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check currency totals: %w", err)
+	}
+	for _, currency := range currencies {
+		report.Currencies = append(report.Currencies, CurrencyDiscrepancy{
+			Currency:     currency.Currency,
+			EntriesTotal: currency.EntriesTotal,
+		})
+	}
+
+	report.OK = len(report.Accounts) == 0 && len(report.Transfers) == 0 && len(report.Currencies) == 0
+	return report, nil
+}
@@ -0,0 +1,107 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/util"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, report *Report, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBalanceMismatches(gomock.Any()).Times(1).Return([]db.ListBalanceMismatchesRow{}, nil)
+				store.EXPECT().ListUnbalancedTransfers(gomock.Any()).Times(1).Return([]db.ListUnbalancedTransfersRow{}, nil)
+				store.EXPECT().ListCurrencyImbalances(gomock.Any()).Times(1).Return([]db.ListCurrencyImbalancesRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, report *Report, err error) {
+				require.NoError(t, err)
+				require.True(t, report.OK)
+
+				// Empty lists are written as `[]`, not `null`
+				data, err := json.Marshal(report)
+				require.NoError(t, err)
+				require.Contains(t, string(data), `"accounts":[]`)
+				require.Contains(t, string(data), `"transfers":[]`)
+				require.Contains(t, string(data), `"currencies":[]`)
+			},
+		},
+		{
+			name: "Discrepancies",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListBalanceMismatches(gomock.Any()).
+					Times(1).
+					Return([]db.ListBalanceMismatchesRow{
+						{ID: 1, Owner: "alice", Currency: util.USD, Balance: 100, EntriesTotal: 70},
+					}, nil)
+				store.EXPECT().
+					ListUnbalancedTransfers(gomock.Any()).
+					Times(1).
+					Return([]db.ListUnbalancedTransfersRow{
+						{ID: 2, FromAccountID: 1, ToAccountID: 3, Amount: 10, EntryCount: 1, EntriesTotal: -10},
+					}, nil)
+				store.EXPECT().
+					ListCurrencyImbalances(gomock.Any()).
+					Times(1).
+					Return([]db.ListCurrencyImbalancesRow{
+						{Currency: util.USD, EntriesTotal: -10},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, report *Report, err error) {
+				require.NoError(t, err)
+				require.False(t, report.OK)
+
+				require.Len(t, report.Accounts, 1)
+				require.Equal(t, int64(1), report.Accounts[0].AccountID)
+				require.Equal(t, int64(30), report.Accounts[0].Difference)
+
+				require.Len(t, report.Transfers, 1)
+				require.Equal(t, int64(2), report.Transfers[0].TransferID)
+				require.Equal(t, int64(1), report.Transfers[0].EntryCount)
+
+				require.Len(t, report.Currencies, 1)
+				require.Equal(t, util.USD, report.Currencies[0].Currency)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListBalanceMismatches(gomock.Any()).Times(1).Return([]db.ListBalanceMismatchesRow{}, nil)
+				store.EXPECT().ListUnbalancedTransfers(gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+				store.EXPECT().ListCurrencyImbalances(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, report *Report, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+				require.Nil(t, report)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			report, err := Reconcile(context.Background(), store)
+			tc.checkResponse(t, report, err)
+		})
+	}
+}
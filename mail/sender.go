@@ -0,0 +1,71 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Sends emails, implementations can be swapped without touching the callers
+type Sender interface {
+	SendEmail(subject string, content string, to []string) error
+}
+
+// Writes every email as an `.eml` file into a directory instead of delivering it.
+// Stands in for an SMTP server in development and tests, the files open in any mail client.
+type FileSender struct {
+	from mail.Address
+	dir  string
+}
+
+func NewFileSender(name string, fromAddress string, dir string) (Sender, error) {
+	if _, err := mail.ParseAddress(fromAddress); err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cannot create outbox directory: %w", err)
+	}
+
+	sender := &FileSender{
+		from: mail.Address{Name: name, Address: fromAddress},
+		dir:  dir,
+	}
+	return sender, nil
+}
+
+func (sender *FileSender) SendEmail(subject string, content string, to []string) error {
+	if len(to) == 0 {
+		return fmt.Errorf("email has no recipients")
+	}
+
+	now := time.Now()
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", sender.from.String())
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&message, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(content)
+
+	// The timestamp keeps the files in the order they were sent
+	name := fmt.Sprintf("%d-%s.eml", now.UnixNano(), sanitizeFileName(to[0]))
+	return os.WriteFile(filepath.Join(sender.dir, name), message.Bytes(), 0o644)
+}
+
+func sanitizeFileName(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_', r == '@':
+			return r
+		}
+		return '_'
+	}, value)
+}
@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSenderSendEmail(t *testing.T) {
+	dir := t.TempDir()
+
+	sender, err := NewFileSender("Simple Bank", "no-reply@simplebank.com", dir)
+	require.NoError(t, err)
+
+	err = sender.SendEmail("Welcome", "<h1>Hello</h1>", []string{"alice@example.com"})
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Contains(t, files[0].Name(), "alice@example.com")
+
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+
+	message := string(data)
+	require.Contains(t, message, "From: \"Simple Bank\" <no-reply@simplebank.com>\r\n")
+	require.Contains(t, message, "To: alice@example.com\r\n")
+	require.Contains(t, message, "Subject: Welcome\r\n")
+	require.Contains(t, message, "\r\n\r\n<h1>Hello</h1>")
+}
+
+func TestFileSenderNoRecipients(t *testing.T) {
+	sender, err := NewFileSender("Simple Bank", "no-reply@simplebank.com", t.TempDir())
+	require.NoError(t, err)
+
+	err = sender.SendEmail("Welcome", "<h1>Hello</h1>", nil)
+	require.Error(t, err)
+}
+
+func TestNewFileSenderInvalidAddress(t *testing.T) {
+	_, err := NewFileSender("Simple Bank", "not an address", t.TempDir())
+	require.Error(t, err)
+}
@@ -10,8 +10,12 @@ import (
 	db "simple-bank/db/sqlc"
 	_ "simple-bank/doc/statik"
 	"simple-bank/gapi"
+	"simple-bank/mail"
 	"simple-bank/pb"
 	"simple-bank/util"
+	"simple-bank/worker"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -45,13 +49,31 @@ func main() {
 	// Run DB migration
 	runDBMigration(config.MigrationURL, config.DBSource)
 
-	store := db.NewStore(conn)
+	store := db.NewStore(conn, db.WithDefaultLimits(db.TransferLimits{
+		PerTransaction: config.TransferLimitPerTransaction,
+		Daily:          config.TransferLimitDaily,
+		Monthly:        config.TransferLimitMonthly,
+	}))
+
+	runCurrencyRefresher(config, store)
+
+	taskDistributor := worker.NewPGTaskDistributor()
 
 	// So config and store are single source of truth being passed to both servers
 	// This is a bit weird, but it's a good way to ensure that the config and store are consistent across both servers.
 	// runGinServer(config, store)
-	go runGatewayServer(config, store)
-	runGrpcServer(config, store)
+	runTaskProcessor(config, store)
+	runTransferScheduler(store)
+	runHoldExpirer(store)
+
+	// Both servers share one gapi.Server, so a blocked session is evicted from a single session cache
+	server, err := gapi.NewServer(config, store, taskDistributor)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create server")
+	}
+
+	go runGatewayServer(config, server)
+	runGrpcServer(config, server)
 }
 
 func runDBMigration(migrationUrl string, dbSource string) {
@@ -67,17 +89,96 @@ func runDBMigration(migrationUrl string, dbSource string) {
 	log.Info().Msg("db migrated successfully")
 }
 
-func runGrpcServer(config util.Config, store db.Store) {
-	server, err := gapi.NewServer(config, store)
+// How often currency changes made by other processes are picked up
+const currencyRefreshInterval = time.Minute
+
+func runCurrencyRefresher(config util.Config, store db.Store) {
+	err := db.LoadCurrencies(context.Background(), store)
 	if err != nil {
-		log.Fatal().Err(err).Msg("cannot create server")
+		log.Fatal().Err(err).Msg("cannot load currencies")
 	}
 
-	// gRPC logger
-	grpcLogger := grpc.UnaryInterceptor(gapi.GrpcLogger)
+	for _, currency := range config.DefaultAccountCurrencies {
+		if !util.IsSupportedCurrency(currency) {
+			log.Warn().Str("currency", currency).Msg("default account currency is not enabled, no accounts will be created in it")
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(currencyRefreshInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := db.LoadCurrencies(context.Background(), store); err != nil {
+				log.Error().Err(err).Msg("cannot refresh currencies")
+			}
+		}
+	}()
+
+	log.Info().Msg("currencies loaded")
+}
+
+const (
+	// How often lapsed holds are expired, until then their funds stay reserved
+	holdExpiryInterval = 10 * time.Second
+	// Holds expired per transaction, the next batch follows right away
+	holdExpiryBatchSize = 100
+)
+
+func runHoldExpirer(store db.Store) {
+	go func() {
+		ticker := time.NewTicker(holdExpiryInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			for {
+				accounts, err := store.ExpireHoldsTx(context.Background(), holdExpiryBatchSize)
+				if err != nil {
+					log.Error().Err(err).Msg("cannot expire holds")
+					break
+				}
+				if accounts == 0 {
+					break
+				}
+				log.Info().Int64("accounts", accounts).Msg("released expired holds")
+			}
+		}
+	}()
+
+	log.Info().Msg("hold expirer started")
+}
+
+func runTaskProcessor(config util.Config, store db.Store) {
+	mailer, err := mail.NewFileSender(config.EmailSenderName, config.EmailSenderAddress, config.MailOutboxDir)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot create mail sender")
+	}
+
+	taskProcessor := worker.NewPGTaskProcessor(store, mailer, config)
+	err = taskProcessor.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start task processor")
+	}
+
+	log.Info().Msg("task processor started")
+}
+
+func runTransferScheduler(store db.Store) {
+	transferScheduler := worker.NewPGTransferScheduler(store)
+	err := transferScheduler.Start()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start transfer scheduler")
+	}
+
+	log.Info().Msg("transfer scheduler started")
+}
+
+func runGrpcServer(config util.Config, server *gapi.Server) {
+	// gRPC logger runs first, so rejected calls are logged too
+	interceptors := grpc.ChainUnaryInterceptor(gapi.GrpcLogger, server.AuthorizationInterceptor)
 
 	// gRPC server
-	grpcServer := grpc.NewServer(grpcLogger)
+	grpcServer := grpc.NewServer(interceptors)
 
 	pb.RegisterSimpleBankServer(grpcServer, server)
 	// This allows a gRPC client to explore what RPC are available in the server
@@ -97,12 +198,7 @@ func runGrpcServer(config util.Config, store db.Store) {
 	}
 }
 
-func runGatewayServer(config util.Config, store db.Store) {
-	server, err := gapi.NewServer(config, store)
-	if err != nil {
-		log.Fatal().Err(err).Msg("cannot create server")
-	}
-
+func runGatewayServer(config util.Config, server *gapi.Server) {
 	grpcMux := runtime.NewServeMux(
 		// Convert camel case response to snake case
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
@@ -113,12 +209,20 @@ func runGatewayServer(config util.Config, store db.Store) {
 				DiscardUnknown: true,
 			},
 		}),
+		// Forward the `Idempotency-Key` header to the gRPC handlers as metadata
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			if strings.EqualFold(key, "Idempotency-Key") {
+				return key, true
+			}
+			return runtime.DefaultHeaderMatcher(key)
+		}),
 	)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	err = pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
+	// In-process calls skip the gRPC interceptors, the handlers still run their own authorization checks
+	err := pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
 	if err != nil {
 		log.Fatal().Err(err).Msg("cannot register http -> grpc gateway handler")
 	}
@@ -0,0 +1,32 @@
+package pagination
+
+import "fmt"
+
+// Lists fetch one row more than the page size to find out if there is a next page
+func Limit(pageSize int32) int32 {
+	return pageSize + 1
+}
+
+// Drops the extra row fetched by `Limit` and reports whether there is a next page
+func Page[T any](rows []T, pageSize int32) ([]T, bool) {
+	if len(rows) > int(pageSize) {
+		return rows[:pageSize], true
+	}
+	return rows, false
+}
+
+func AccountsScope(owner string) string {
+	return fmt.Sprintf("accounts:%s", owner)
+}
+
+func EntriesScope(accountID int64) string {
+	return fmt.Sprintf("entries:%d", accountID)
+}
+
+func TransfersScope(accountID int64) string {
+	return fmt.Sprintf("transfers:%d", accountID)
+}
+
+func ScheduledTransfersScope(owner string) string {
+	return fmt.Sprintf("scheduled_transfers:%s", owner)
+}
@@ -0,0 +1,92 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Upper bound of the page size of every list endpoint
+const MaxPageSize = 100
+
+var ErrInvalidPageToken = errors.New("page token is invalid")
+
+// Keyset cursor carried by an opaque page token.
+// Every list is sorted by id, so the last seen id is the sort key of the next page.
+type Cursor struct {
+	// Identifies the list the token was issued for, eg: "accounts:alice".
+	// A token cannot be used to page through a different list.
+	Scope  string `json:"scope"`
+	LastID int64  `json:"last_id"`
+}
+
+// Signs and verifies page tokens so clients cannot forge cursors
+type Maker struct {
+	secretKey []byte
+}
+
+func NewMaker(secretKey string) (*Maker, error) {
+	if len(secretKey) < 32 {
+		return nil, fmt.Errorf("invalid key size: must be at least %d characters", 32)
+	}
+
+	return &Maker{secretKey: []byte(secretKey)}, nil
+}
+
+// Creates the token of the page that starts after `lastID`
+func (maker *Maker) CreateToken(scope string, lastID int64) (string, error) {
+	payload, err := json.Marshal(Cursor{Scope: scope, LastID: lastID})
+	if err != nil {
+		return "", err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	signature := base64.RawURLEncoding.EncodeToString(maker.sign(encodedPayload))
+	return encodedPayload + "." + signature, nil
+}
+
+// Returns the id after which the next page starts.
+// An empty token means the first page, so 0 is returned.
+func (maker *Maker) VerifyToken(token string, scope string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, ErrInvalidPageToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, maker.sign(encodedPayload)) {
+		return 0, ErrInvalidPageToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return 0, ErrInvalidPageToken
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return 0, ErrInvalidPageToken
+	}
+
+	if cursor.Scope != scope {
+		return 0, ErrInvalidPageToken
+	}
+
+	return cursor.LastID, nil
+}
+
+func (maker *Maker) sign(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, maker.secretKey)
+	// Keeps page token signatures apart from anything else signed with the same key
+	mac.Write([]byte("page_token:"))
+	mac.Write([]byte(encodedPayload))
+	return mac.Sum(nil)
+}
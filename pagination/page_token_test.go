@@ -0,0 +1,88 @@
+package pagination
+
+import (
+	"simple-bank/util"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	maker, err := NewMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	scope := "accounts:" + util.RandomOwner()
+	lastID := util.RandomInt(1, 1000)
+
+	token, err := maker.CreateToken(scope, lastID)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	gotLastID, err := maker.VerifyToken(token, scope)
+	require.NoError(t, err)
+	require.Equal(t, lastID, gotLastID)
+}
+
+func TestEmptyPageToken(t *testing.T) {
+	maker, err := NewMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	lastID, err := maker.VerifyToken("", "accounts:user")
+	require.NoError(t, err)
+	require.Zero(t, lastID)
+}
+
+func TestPageTokenWrongScope(t *testing.T) {
+	maker, err := NewMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	token, err := maker.CreateToken("accounts:alice", 10)
+	require.NoError(t, err)
+
+	_, err = maker.VerifyToken(token, "accounts:bob")
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestTamperedPageToken(t *testing.T) {
+	maker, err := NewMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	token, err := maker.CreateToken("accounts:alice", 10)
+	require.NoError(t, err)
+
+	// Signed with a different key
+	otherMaker, err := NewMaker(util.RandomString(32))
+	require.NoError(t, err)
+	_, err = otherMaker.VerifyToken(token, "accounts:alice")
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+
+	// Payload of another token with the original signature
+	otherToken, err := maker.CreateToken("accounts:alice", 99)
+	require.NoError(t, err)
+	otherPayload, _, _ := strings.Cut(otherToken, ".")
+	_, signature, _ := strings.Cut(token, ".")
+	_, err = maker.VerifyToken(otherPayload+"."+signature, "accounts:alice")
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, err = maker.VerifyToken("not-a-token", "accounts:alice")
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestInvalidKeySize(t *testing.T) {
+	maker, err := NewMaker(util.RandomString(31))
+	require.Error(t, err)
+	require.Nil(t, maker)
+}
+
+func TestPage(t *testing.T) {
+	rows := []int64{1, 2, 3}
+
+	page, hasNextPage := Page(rows, 2)
+	require.Equal(t, []int64{1, 2}, page)
+	require.True(t, hasNextPage)
+
+	page, hasNextPage = Page(rows, 3)
+	require.Equal(t, rows, page)
+	require.False(t, hasNextPage)
+}
@@ -0,0 +1,188 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v6.32.0
+// source: rpc_get_transfer_fee.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetTransferFeeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     int64                  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferFeeRequest) Reset() {
+	*x = GetTransferFeeRequest{}
+	mi := &file_rpc_get_transfer_fee_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferFeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferFeeRequest) ProtoMessage() {}
+
+func (x *GetTransferFeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_transfer_fee_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferFeeRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferFeeRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_get_transfer_fee_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetTransferFeeRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *GetTransferFeeRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type GetTransferFeeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fee           int64                  `protobuf:"varint,1,opt,name=fee,proto3" json:"fee,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferFeeResponse) Reset() {
+	*x = GetTransferFeeResponse{}
+	mi := &file_rpc_get_transfer_fee_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferFeeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferFeeResponse) ProtoMessage() {}
+
+func (x *GetTransferFeeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_get_transfer_fee_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferFeeResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferFeeResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_get_transfer_fee_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTransferFeeResponse) GetFee() int64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *GetTransferFeeResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+var File_rpc_get_transfer_fee_proto protoreflect.FileDescriptor
+
+const file_rpc_get_transfer_fee_proto_rawDesc = "" +
+	"\n" +
+	"\x1arpc_get_transfer_fee.proto\x12\x02pb\"N\n" +
+	"\x15GetTransferFeeRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"F\n" +
+	"\x16GetTransferFeeResponse\x12\x10\n" +
+	"\x03fee\x18\x01 \x01(\x03R\x03fee\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrencyB\x10Z\x0esimple-bank/pbb\x06proto3"
+
+var (
+	file_rpc_get_transfer_fee_proto_rawDescOnce sync.Once
+	file_rpc_get_transfer_fee_proto_rawDescData []byte
+)
+
+func file_rpc_get_transfer_fee_proto_rawDescGZIP() []byte {
+	file_rpc_get_transfer_fee_proto_rawDescOnce.Do(func() {
+		file_rpc_get_transfer_fee_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_get_transfer_fee_proto_rawDesc), len(file_rpc_get_transfer_fee_proto_rawDesc)))
+	})
+	return file_rpc_get_transfer_fee_proto_rawDescData
+}
+
+var file_rpc_get_transfer_fee_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_get_transfer_fee_proto_goTypes = []any{
+	(*GetTransferFeeRequest)(nil),  // 0: pb.GetTransferFeeRequest
+	(*GetTransferFeeResponse)(nil), // 1: pb.GetTransferFeeResponse
+}
+var file_rpc_get_transfer_fee_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rpc_get_transfer_fee_proto_init() }
+func file_rpc_get_transfer_fee_proto_init() {
+	if File_rpc_get_transfer_fee_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_get_transfer_fee_proto_rawDesc), len(file_rpc_get_transfer_fee_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_get_transfer_fee_proto_goTypes,
+		DependencyIndexes: file_rpc_get_transfer_fee_proto_depIdxs,
+		MessageInfos:      file_rpc_get_transfer_fee_proto_msgTypes,
+	}.Build()
+	File_rpc_get_transfer_fee_proto = out.File
+	file_rpc_get_transfer_fee_proto_goTypes = nil
+	file_rpc_get_transfer_fee_proto_depIdxs = nil
+}
@@ -0,0 +1,215 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v6.32.0
+// source: rpc_set_transfer_limit.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SetTransferLimitRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Currency       string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	AccountId      *int64                 `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	PerTransaction *int64                 `protobuf:"varint,3,opt,name=per_transaction,json=perTransaction,proto3,oneof" json:"per_transaction,omitempty"`
+	Daily          *int64                 `protobuf:"varint,4,opt,name=daily,proto3,oneof" json:"daily,omitempty"`
+	Monthly        *int64                 `protobuf:"varint,5,opt,name=monthly,proto3,oneof" json:"monthly,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetTransferLimitRequest) Reset() {
+	*x = SetTransferLimitRequest{}
+	mi := &file_rpc_set_transfer_limit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTransferLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTransferLimitRequest) ProtoMessage() {}
+
+func (x *SetTransferLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_transfer_limit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTransferLimitRequest.ProtoReflect.Descriptor instead.
+func (*SetTransferLimitRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_set_transfer_limit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetTransferLimitRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SetTransferLimitRequest) GetAccountId() int64 {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return 0
+}
+
+func (x *SetTransferLimitRequest) GetPerTransaction() int64 {
+	if x != nil && x.PerTransaction != nil {
+		return *x.PerTransaction
+	}
+	return 0
+}
+
+func (x *SetTransferLimitRequest) GetDaily() int64 {
+	if x != nil && x.Daily != nil {
+		return *x.Daily
+	}
+	return 0
+}
+
+func (x *SetTransferLimitRequest) GetMonthly() int64 {
+	if x != nil && x.Monthly != nil {
+		return *x.Monthly
+	}
+	return 0
+}
+
+type SetTransferLimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         *TransferLimit         `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTransferLimitResponse) Reset() {
+	*x = SetTransferLimitResponse{}
+	mi := &file_rpc_set_transfer_limit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTransferLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTransferLimitResponse) ProtoMessage() {}
+
+func (x *SetTransferLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_set_transfer_limit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTransferLimitResponse.ProtoReflect.Descriptor instead.
+func (*SetTransferLimitResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_set_transfer_limit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetTransferLimitResponse) GetLimit() *TransferLimit {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+var File_rpc_set_transfer_limit_proto protoreflect.FileDescriptor
+
+const file_rpc_set_transfer_limit_proto_rawDesc = "" +
+	"\n" +
+	"\x1crpc_set_transfer_limit.proto\x12\x02pb\x1a\x14transfer_limit.proto\"\xfa\x01\n" +
+	"\x17SetTransferLimitRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\"\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\x03H\x00R\taccountId\x88\x01\x01\x12,\n" +
+	"\x0fper_transaction\x18\x03 \x01(\x03H\x01R\x0eperTransaction\x88\x01\x01\x12\x19\n" +
+	"\x05daily\x18\x04 \x01(\x03H\x02R\x05daily\x88\x01\x01\x12\x1d\n" +
+	"\amonthly\x18\x05 \x01(\x03H\x03R\amonthly\x88\x01\x01B\r\n" +
+	"\v_account_idB\x12\n" +
+	"\x10_per_transactionB\b\n" +
+	"\x06_dailyB\n" +
+	"\n" +
+	"\b_monthly\"C\n" +
+	"\x18SetTransferLimitResponse\x12'\n" +
+	"\x05limit\x18\x01 \x01(\v2\x11.pb.TransferLimitR\x05limitB\x10Z\x0esimple-bank/pbb\x06proto3"
+
+var (
+	file_rpc_set_transfer_limit_proto_rawDescOnce sync.Once
+	file_rpc_set_transfer_limit_proto_rawDescData []byte
+)
+
+func file_rpc_set_transfer_limit_proto_rawDescGZIP() []byte {
+	file_rpc_set_transfer_limit_proto_rawDescOnce.Do(func() {
+		file_rpc_set_transfer_limit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_set_transfer_limit_proto_rawDesc), len(file_rpc_set_transfer_limit_proto_rawDesc)))
+	})
+	return file_rpc_set_transfer_limit_proto_rawDescData
+}
+
+var file_rpc_set_transfer_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_set_transfer_limit_proto_goTypes = []any{
+	(*SetTransferLimitRequest)(nil),  // 0: pb.SetTransferLimitRequest
+	(*SetTransferLimitResponse)(nil), // 1: pb.SetTransferLimitResponse
+	(*TransferLimit)(nil),            // 2: pb.TransferLimit
+}
+var file_rpc_set_transfer_limit_proto_depIdxs = []int32{
+	2, // 0: pb.SetTransferLimitResponse.limit:type_name -> pb.TransferLimit
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_set_transfer_limit_proto_init() }
+func file_rpc_set_transfer_limit_proto_init() {
+	if File_rpc_set_transfer_limit_proto != nil {
+		return
+	}
+	file_transfer_limit_proto_init()
+	file_rpc_set_transfer_limit_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_set_transfer_limit_proto_rawDesc), len(file_rpc_set_transfer_limit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_set_transfer_limit_proto_goTypes,
+		DependencyIndexes: file_rpc_set_transfer_limit_proto_depIdxs,
+		MessageInfos:      file_rpc_set_transfer_limit_proto_msgTypes,
+	}.Build()
+	File_rpc_set_transfer_limit_proto = out.File
+	file_rpc_set_transfer_limit_proto_goTypes = nil
+	file_rpc_set_transfer_limit_proto_depIdxs = nil
+}
@@ -0,0 +1,182 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v6.32.0
+// source: rpc_update_account_overdraft_limit.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UpdateAccountOverdraftLimitRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// How far below zero the balance of the account may go, 0 means it can't be overdrawn
+	OverdraftLimit int64 `protobuf:"varint,2,opt,name=overdraft_limit,json=overdraftLimit,proto3" json:"overdraft_limit,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateAccountOverdraftLimitRequest) Reset() {
+	*x = UpdateAccountOverdraftLimitRequest{}
+	mi := &file_rpc_update_account_overdraft_limit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountOverdraftLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountOverdraftLimitRequest) ProtoMessage() {}
+
+func (x *UpdateAccountOverdraftLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_update_account_overdraft_limit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountOverdraftLimitRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAccountOverdraftLimitRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_update_account_overdraft_limit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpdateAccountOverdraftLimitRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateAccountOverdraftLimitRequest) GetOverdraftLimit() int64 {
+	if x != nil {
+		return x.OverdraftLimit
+	}
+	return 0
+}
+
+type UpdateAccountOverdraftLimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAccountOverdraftLimitResponse) Reset() {
+	*x = UpdateAccountOverdraftLimitResponse{}
+	mi := &file_rpc_update_account_overdraft_limit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountOverdraftLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountOverdraftLimitResponse) ProtoMessage() {}
+
+func (x *UpdateAccountOverdraftLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_update_account_overdraft_limit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountOverdraftLimitResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAccountOverdraftLimitResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_update_account_overdraft_limit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpdateAccountOverdraftLimitResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+var File_rpc_update_account_overdraft_limit_proto protoreflect.FileDescriptor
+
+const file_rpc_update_account_overdraft_limit_proto_rawDesc = "" +
+	"\n" +
+	"(rpc_update_account_overdraft_limit.proto\x12\x02pb\x1a\raccount.proto\"]\n" +
+	"\"UpdateAccountOverdraftLimitRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12'\n" +
+	"\x0foverdraft_limit\x18\x02 \x01(\x03R\x0eoverdraftLimit\"L\n" +
+	"#UpdateAccountOverdraftLimitResponse\x12%\n" +
+	"\aaccount\x18\x01 \x01(\v2\v.pb.AccountR\aaccountB\x10Z\x0esimple-bank/pbb\x06proto3"
+
+var (
+	file_rpc_update_account_overdraft_limit_proto_rawDescOnce sync.Once
+	file_rpc_update_account_overdraft_limit_proto_rawDescData []byte
+)
+
+func file_rpc_update_account_overdraft_limit_proto_rawDescGZIP() []byte {
+	file_rpc_update_account_overdraft_limit_proto_rawDescOnce.Do(func() {
+		file_rpc_update_account_overdraft_limit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_update_account_overdraft_limit_proto_rawDesc), len(file_rpc_update_account_overdraft_limit_proto_rawDesc)))
+	})
+	return file_rpc_update_account_overdraft_limit_proto_rawDescData
+}
+
+var file_rpc_update_account_overdraft_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rpc_update_account_overdraft_limit_proto_goTypes = []any{
+	(*UpdateAccountOverdraftLimitRequest)(nil),  // 0: pb.UpdateAccountOverdraftLimitRequest
+	(*UpdateAccountOverdraftLimitResponse)(nil), // 1: pb.UpdateAccountOverdraftLimitResponse
+	(*Account)(nil), // 2: pb.Account
+}
+var file_rpc_update_account_overdraft_limit_proto_depIdxs = []int32{
+	2, // 0: pb.UpdateAccountOverdraftLimitResponse.account:type_name -> pb.Account
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rpc_update_account_overdraft_limit_proto_init() }
+func file_rpc_update_account_overdraft_limit_proto_init() {
+	if File_rpc_update_account_overdraft_limit_proto != nil {
+		return
+	}
+	file_account_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_update_account_overdraft_limit_proto_rawDesc), len(file_rpc_update_account_overdraft_limit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_update_account_overdraft_limit_proto_goTypes,
+		DependencyIndexes: file_rpc_update_account_overdraft_limit_proto_depIdxs,
+		MessageInfos:      file_rpc_update_account_overdraft_limit_proto_msgTypes,
+	}.Build()
+	File_rpc_update_account_overdraft_limit_proto = out.File
+	file_rpc_update_account_overdraft_limit_proto_goTypes = nil
+	file_rpc_update_account_overdraft_limit_proto_depIdxs = nil
+}
@@ -26,7 +26,7 @@ var File_service_simple_bank_proto protoreflect.FileDescriptor
 
 const file_service_simple_bank_proto_rawDesc = "" +
 	"\n" +
-	"\x19service_simple_bank.proto\x12\x02pb\x1a\x15rpc_create_user.proto\x1a\x14rpc_login_user.proto\x1a\x15rpc_update_user.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xdf\x03\n" +
+	"\x19service_simple_bank.proto\x12\x02pb\x1a\x15rpc_create_user.proto\x1a\x14rpc_login_user.proto\x1a\x15rpc_update_user.proto\x1a\x18rpc_create_account.proto\x1a\x15rpc_get_account.proto\x1a\x17rpc_list_accounts.proto\x1a\x19rpc_create_transfer.proto\x1a\x1erpc_list_account_entries.proto\x1a rpc_list_account_transfers.proto\x1a\x1crpc_renew_access_token.proto\x1a\x15rpc_logout_user.proto\x1a\x17rpc_list_sessions.proto\x1a\x18rpc_revoke_session.proto\x1a\x1drpc_revoke_all_sessions.proto\x1a\x16rpc_verify_email.proto\x1a\x11rpc_deposit.proto\x1a\x12rpc_withdraw.proto\x1a\x19rpc_list_currencies.proto\x1a\x19rpc_create_currency.proto\x1a\x19rpc_update_currency.proto\x1a#rpc_create_scheduled_transfer.proto\x1a rpc_get_scheduled_transfer.proto\x1a\"rpc_list_scheduled_transfers.proto\x1a#rpc_update_scheduled_transfer.proto\x1a#rpc_delete_scheduled_transfer.proto\x1a\x14rpc_place_hold.proto\x1a\x16rpc_capture_hold.proto\x1a\x16rpc_release_hold.proto\x1a\x1arpc_reverse_transfer.proto\x1a\x1brpc_complete_transfer.proto\x1a\x19rpc_cancel_transfer.proto\x1a\x1crpc_set_transfer_limit.proto\x1a%rpc_get_account_transfer_limits.proto\x1a(rpc_update_account_overdraft_limit.proto\x1a\x1arpc_get_transfer_fee.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xa9;\n" +
 	"\n" +
 	"SimpleBank\x12\xa0\x01\n" +
 	"\n" +
@@ -38,32 +38,228 @@ const file_service_simple_bank_proto_rawDesc = "" +
 	"\tLoginUser\x12\x14.pb.LoginUserRequest\x1a\x15.pb.LoginUserResponse\"X\x92A<\n" +
 	"\n" +
 	"login_user\x12\n" +
-	"Login User\x1a\"This API logs in a user using gRPC\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/login_userB\xfa\x01\x92A\xe6\x01\x12\xe3\x01\n" +
+	"Login User\x1a\"This API logs in a user using gRPC\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/login_user\x12\xcd\x01\n" +
+	"\rCreateAccount\x12\x18.pb.CreateAccountRequest\x1a\x19.pb.CreateAccountResponse\"\x86\x01\x92Af\n" +
+	"\x0ecreate_account\x12\x12Create new Account\x1a@This API creates a new account for the logged in user using gRPC\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v1/create_account\x12\xae\x01\n" +
+	"\n" +
+	"GetAccount\x12\x15.pb.GetAccountRequest\x1a\x16.pb.GetAccountResponse\"q\x92AU\n" +
+	"\vget_account\x12\vGet Account\x1a9This API gets an account of the logged in user using gRPC\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/accounts/{id}\x12\xb6\x01\n" +
+	"\fListAccounts\x12\x17.pb.ListAccountsRequest\x1a\x18.pb.ListAccountsResponse\"s\x92A\\\n" +
+	"\rlist_accounts\x12\rList Accounts\x1a<This API lists the accounts of the logged in user using gRPC\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/accounts\x12\xcb\x01\n" +
+	"\x0eCreateTransfer\x12\x19.pb.CreateTransferRequest\x1a\x1a.pb.CreateTransferResponse\"\x81\x01\x92A`\n" +
+	"\x0fcreate_transfer\x12\x13Create new Transfer\x1a8This API transfers money between two accounts using gRPC\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/create_transfer\x12\xf9\x01\n" +
+	"\x12ListAccountEntries\x12\x1d.pb.ListAccountEntriesRequest\x1a\x1e.pb.ListAccountEntriesResponse\"\xa3\x01\x92Aw\n" +
+	"\x14list_account_entries\x12\x14List Account Entries\x1aIThis API lists the entries of an account of the logged in user using gRPC\x82\xd3\xe4\x93\x02#\x12!/v1/accounts/{account_id}/entries\x12\x93\x02\n" +
+	"\x14ListAccountTransfers\x12\x1f.pb.ListAccountTransfersRequest\x1a .pb.ListAccountTransfersResponse\"\xb7\x01\x92A\x88\x01\n" +
+	"\x16list_account_transfers\x12\x16List Account Transfers\x1aVThis API lists the transfers in and out of an account of the logged in user using gRPC\x82\xd3\xe4\x93\x02%\x12#/v1/accounts/{account_id}/transfers\x12\xe1\x01\n" +
+	"\x10RenewAccessToken\x12\x1b.pb.RenewAccessTokenRequest\x1a\x1c.pb.RenewAccessTokenResponse\"\x91\x01\x92As\n" +
+	"\x12renew_access_token\x12\x12Renew Access Token\x1aIThis API renews the access token and rotates the refresh token using gRPC\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/tokens/renew\x12\xad\x01\n" +
+	"\n" +
+	"LogoutUser\x12\x15.pb.LogoutUserRequest\x1a\x16.pb.LogoutUserResponse\"p\x92AS\n" +
+	"\vlogout_user\x12\vLogout user\x1a7This API ends the session of a refresh token using gRPC\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/logout_user\x12\xbd\x01\n" +
+	"\fListSessions\x12\x17.pb.ListSessionsRequest\x1a\x18.pb.ListSessionsResponse\"z\x92Ac\n" +
+	"\rlist_sessions\x12\rList Sessions\x1aCThis API lists the active sessions of the logged in user using gRPC\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/sessions\x12\xc8\x01\n" +
+	"\rRevokeSession\x12\x18.pb.RevokeSessionRequest\x1a\x19.pb.RevokeSessionResponse\"\x81\x01\x92A]\n" +
+	"\x0erevoke_session\x12\x0eRevoke Session\x1a;This API revokes a session of the logged in user using gRPC\x82\xd3\xe4\x93\x02\x1b*\x19/v1/sessions/{session_id}\x12\xd8\x01\n" +
+	"\x11RevokeAllSessions\x12\x1c.pb.RevokeAllSessionsRequest\x1a\x1d.pb.RevokeAllSessionsResponse\"\x85\x01\x92An\n" +
+	"\x13revoke_all_sessions\x12\x13Revoke All Sessions\x1aBThis API revokes all the sessions of the logged in user using gRPC\x82\xd3\xe4\x93\x02\x0e*\f/v1/sessions\x12\xb1\x01\n" +
+	"\vVerifyEmail\x12\x16.pb.VerifyEmailRequest\x1a\x17.pb.VerifyEmailResponse\"q\x92AV\n" +
+	"\fverify_email\x12\fVerify email\x1a8This API verifies the email address of a user using gRPC\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/verify_email\x12\xb2\x01\n" +
+	"\aDeposit\x12\x12.pb.DepositRequest\x1a\x13.pb.DepositResponse\"~\x92Ae\n" +
+	"\adeposit\x12\aDeposit\x1aQThis API deposits cash into an account, it can only be used by bankers using gRPC\x82\xd3\xe4\x93\x02\x10:\x01*\"\v/v1/deposit\x12\xba\x01\n" +
+	"\bWithdraw\x12\x13.pb.WithdrawRequest\x1a\x14.pb.WithdrawResponse\"\x82\x01\x92Ah\n" +
+	"\bwithdraw\x12\bWithdraw\x1aRThis API withdraws cash from an account, it can only be used by bankers using gRPC\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/withdraw\x12\xc4\x01\n" +
+	"\x0eListCurrencies\x12\x19.pb.ListCurrenciesRequest\x1a\x1a.pb.ListCurrenciesResponse\"{\x92Ab\n" +
+	"\x0flist_currencies\x12\x0fList currencies\x1a>This API lists the currencies and their minor units using gRPC\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/currencies\x12\xcd\x01\n" +
+	"\x0eCreateCurrency\x12\x19.pb.CreateCurrencyRequest\x1a\x1a.pb.CreateCurrencyResponse\"\x83\x01\x92Ag\n" +
+	"\x0fcreate_currency\x12\x0fCreate currency\x1aCThis API adds a currency, it can only be used by bankers using gRPC\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/currencies\x12\xe3\x01\n" +
+	"\x0eUpdateCurrency\x12\x19.pb.UpdateCurrencyRequest\x1a\x1a.pb.UpdateCurrencyResponse\"\x99\x01\x92Av\n" +
+	"\x0fupdate_currency\x12\x0fUpdate currency\x1aRThis API enables or disables a currency, it can only be used by bankers using gRPC\x82\xd3\xe4\x93\x02\x1a:\x01*2\x15/v1/currencies/{code}\x12\xff\x01\n" +
+	"\x17CreateScheduledTransfer\x12\".pb.CreateScheduledTransferRequest\x1a#.pb.CreateScheduledTransferResponse\"\x9a\x01\x92Au\n" +
+	"\x19create_scheduled_transfer\x12\x19Create scheduled transfer\x1a=This API schedules a one-off or recurring transfer using gRPC\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/scheduled_transfers\x12\xf6\x01\n" +
+	"\x14GetScheduledTransfer\x12\x1f.pb.GetScheduledTransferRequest\x1a .pb.GetScheduledTransferResponse\"\x9a\x01\x92As\n" +
+	"\x16get_scheduled_transfer\x12\x16Get scheduled transfer\x1aAThis API gets a scheduled transfer and its recent runs using gRPC\x82\xd3\xe4\x93\x02\x1e\x12\x1c/v1/scheduled_transfers/{id}\x12\x81\x02\n" +
+	"\x16ListScheduledTransfers\x12!.pb.ListScheduledTransfersRequest\x1a\".pb.ListScheduledTransfersResponse\"\x9f\x01\x92A}\n" +
+	"\x18list_scheduled_transfers\x12\x18List scheduled transfers\x1aGThis API lists the scheduled transfers of the logged in user using gRPC\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/scheduled_transfers\x12\x93\x02\n" +
+	"\x17UpdateScheduledTransfer\x12\".pb.UpdateScheduledTransferRequest\x1a#.pb.UpdateScheduledTransferResponse\"\xae\x01\x92A\x83\x01\n" +
+	"\x19update_scheduled_transfer\x12\x19Update scheduled transfer\x1aKThis API changes the amount of a scheduled transfer or pauses it using gRPC\x82\xd3\xe4\x93\x02!:\x01*2\x1c/v1/scheduled_transfers/{id}\x12\xf4\x01\n" +
+	"\x17DeleteScheduledTransfer\x12\".pb.DeleteScheduledTransferRequest\x1a#.pb.DeleteScheduledTransferResponse\"\x8f\x01\x92Ah\n" +
+	"\x19delete_scheduled_transfer\x12\x19Delete scheduled transfer\x1a0This API cancels a scheduled transfer using gRPC\x82\xd3\xe4\x93\x02\x1e*\x1c/v1/scheduled_transfers/{id}\x12\xb0\x01\n" +
+	"\tPlaceHold\x12\x14.pb.PlaceHoldRequest\x1a\x15.pb.PlaceHoldResponse\"v\x92A_\n" +
+	"\n" +
+	"place_hold\x12\n" +
+	"Place hold\x1aEThis API reserves funds on an account for a later transfer using gRPC\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/holds\x12\xb4\x01\n" +
+	"\vCaptureHold\x12\x16.pb.CaptureHoldRequest\x1a\x17.pb.CaptureHoldResponse\"t\x92AP\n" +
+	"\fcapture_hold\x12\fCapture hold\x1a2This API settles a hold with a transfer using gRPC\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/holds/{id}/capture\x12\xbb\x01\n" +
+	"\vReleaseHold\x12\x16.pb.ReleaseHoldRequest\x1a\x17.pb.ReleaseHoldResponse\"{\x92AW\n" +
+	"\frelease_hold\x12\fRelease hold\x1a9This API releases the funds reserved by a hold using gRPC\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/holds/{id}/release\x12\xd9\x01\n" +
+	"\x0fReverseTransfer\x12\x1a.pb.ReverseTransferRequest\x1a\x1b.pb.ReverseTransferResponse\"\x8c\x01\x92A[\n" +
+	"\x10reverse_transfer\x12\x10Reverse transfer\x1a5This API refunds all or part of a transfer using gRPC\x82\xd3\xe4\x93\x02(:\x01*\"#/v1/transfers/{transfer_id}/reverse\x12\xda\x01\n" +
+	"\x10CompleteTransfer\x12\x1b.pb.CompleteTransferRequest\x1a\x1c.pb.CompleteTransferResponse\"\x8a\x01\x92Aa\n" +
+	"\x11complete_transfer\x12\x11Complete transfer\x1a9This API moves the money of a pending transfer using gRPC\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/v1/transfers/{id}/complete\x12\xc2\x01\n" +
+	"\x0eCancelTransfer\x12\x19.pb.CancelTransferRequest\x1a\x1a.pb.CancelTransferResponse\"y\x92AR\n" +
+	"\x0fcancel_transfer\x12\x0fCancel transfer\x1a.This API cancels a pending transfer using gRPC\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v1/transfers/{id}/cancel\x12\x84\x02\n" +
+	"\x10SetTransferLimit\x12\x1b.pb.SetTransferLimitRequest\x1a\x1c.pb.SetTransferLimitResponse\"\xb4\x01\x92A\x92\x01\n" +
+	"\x12set_transfer_limit\x12\x12Set transfer limit\x1ahThis API sets the outgoing limits of a currency or an account, it can only be used by bankers using gRPC\x82\xd3\xe4\x93\x02\x18:\x01*\x1a\x13/v1/transfer_limits\x12\xa1\x02\n" +
+	"\x18GetAccountTransferLimits\x12#.pb.GetAccountTransferLimitsRequest\x1a$.pb.GetAccountTransferLimitsResponse\"\xb9\x01\x92A\x84\x01\n" +
+	"\x1bget_account_transfer_limits\x12\x1bGet account transfer limits\x1aHThis API returns the outgoing limits that apply to an account using gRPC\x82\xd3\xe4\x93\x02+\x12)/v1/accounts/{account_id}/transfer_limits\x12\xbd\x02\n" +
+	"\x1bUpdateAccountOverdraftLimit\x12&.pb.UpdateAccountOverdraftLimitRequest\x1a'.pb.UpdateAccountOverdraftLimitResponse\"\xcc\x01\x92A\x9c\x01\n" +
+	"\x1eupdate_account_overdraft_limit\x12\x1eUpdate account overdraft limit\x1aZThis API sets the overdraft limit of an account, it can only be used by bankers using gRPC\x82\xd3\xe4\x93\x02&:\x01*2!/v1/accounts/{id}/overdraft_limit\x12\xf3\x01\n" +
+	"\x0eGetTransferFee\x12\x19.pb.GetTransferFeeRequest\x1a\x1a.pb.GetTransferFeeResponse\"\xa9\x01\x92Ax\n" +
+	"\x10get_transfer_fee\x12\x10Get transfer fee\x1aRThis API quotes the fee of a transfer from an account without making it using gRPC\x82\xd3\xe4\x93\x02(\x12&/v1/accounts/{account_id}/transfer_feeB\xfa\x01\x92A\xe6\x01\x12\xe3\x01\n" +
 	"\x0fSimple Bank API\"O\n" +
 	"\x10Personal Project\x12)https://github.com/go-backend-development\x1a\x10none@example.com*X\n" +
 	"\x14BSD 3-Clause License\x12@https://github.com/grpc-ecosystem/grpc-gateway/blob/main/LICENSE2\x031.2: \n" +
 	"\x15x-something-something\x12\a\x1a\x05yaddaZ\x0esimple-bank/pbb\x06proto3"
 
 var file_service_simple_bank_proto_goTypes = []any{
-	(*CreateUserRequest)(nil),  // 0: pb.CreateUserRequest
-	(*UpdateUserRequest)(nil),  // 1: pb.UpdateUserRequest
-	(*LoginUserRequest)(nil),   // 2: pb.LoginUserRequest
-	(*CreateUserResponse)(nil), // 3: pb.CreateUserResponse
-	(*UpdateUserResponse)(nil), // 4: pb.UpdateUserResponse
-	(*LoginUserResponse)(nil),  // 5: pb.LoginUserResponse
+	(*CreateUserRequest)(nil),                   // 0: pb.CreateUserRequest
+	(*UpdateUserRequest)(nil),                   // 1: pb.UpdateUserRequest
+	(*LoginUserRequest)(nil),                    // 2: pb.LoginUserRequest
+	(*CreateAccountRequest)(nil),                // 3: pb.CreateAccountRequest
+	(*GetAccountRequest)(nil),                   // 4: pb.GetAccountRequest
+	(*ListAccountsRequest)(nil),                 // 5: pb.ListAccountsRequest
+	(*CreateTransferRequest)(nil),               // 6: pb.CreateTransferRequest
+	(*ListAccountEntriesRequest)(nil),           // 7: pb.ListAccountEntriesRequest
+	(*ListAccountTransfersRequest)(nil),         // 8: pb.ListAccountTransfersRequest
+	(*RenewAccessTokenRequest)(nil),             // 9: pb.RenewAccessTokenRequest
+	(*LogoutUserRequest)(nil),                   // 10: pb.LogoutUserRequest
+	(*ListSessionsRequest)(nil),                 // 11: pb.ListSessionsRequest
+	(*RevokeSessionRequest)(nil),                // 12: pb.RevokeSessionRequest
+	(*RevokeAllSessionsRequest)(nil),            // 13: pb.RevokeAllSessionsRequest
+	(*VerifyEmailRequest)(nil),                  // 14: pb.VerifyEmailRequest
+	(*DepositRequest)(nil),                      // 15: pb.DepositRequest
+	(*WithdrawRequest)(nil),                     // 16: pb.WithdrawRequest
+	(*ListCurrenciesRequest)(nil),               // 17: pb.ListCurrenciesRequest
+	(*CreateCurrencyRequest)(nil),               // 18: pb.CreateCurrencyRequest
+	(*UpdateCurrencyRequest)(nil),               // 19: pb.UpdateCurrencyRequest
+	(*CreateScheduledTransferRequest)(nil),      // 20: pb.CreateScheduledTransferRequest
+	(*GetScheduledTransferRequest)(nil),         // 21: pb.GetScheduledTransferRequest
+	(*ListScheduledTransfersRequest)(nil),       // 22: pb.ListScheduledTransfersRequest
+	(*UpdateScheduledTransferRequest)(nil),      // 23: pb.UpdateScheduledTransferRequest
+	(*DeleteScheduledTransferRequest)(nil),      // 24: pb.DeleteScheduledTransferRequest
+	(*PlaceHoldRequest)(nil),                    // 25: pb.PlaceHoldRequest
+	(*CaptureHoldRequest)(nil),                  // 26: pb.CaptureHoldRequest
+	(*ReleaseHoldRequest)(nil),                  // 27: pb.ReleaseHoldRequest
+	(*ReverseTransferRequest)(nil),              // 28: pb.ReverseTransferRequest
+	(*CompleteTransferRequest)(nil),             // 29: pb.CompleteTransferRequest
+	(*CancelTransferRequest)(nil),               // 30: pb.CancelTransferRequest
+	(*SetTransferLimitRequest)(nil),             // 31: pb.SetTransferLimitRequest
+	(*GetAccountTransferLimitsRequest)(nil),     // 32: pb.GetAccountTransferLimitsRequest
+	(*UpdateAccountOverdraftLimitRequest)(nil),  // 33: pb.UpdateAccountOverdraftLimitRequest
+	(*GetTransferFeeRequest)(nil),               // 34: pb.GetTransferFeeRequest
+	(*CreateUserResponse)(nil),                  // 35: pb.CreateUserResponse
+	(*UpdateUserResponse)(nil),                  // 36: pb.UpdateUserResponse
+	(*LoginUserResponse)(nil),                   // 37: pb.LoginUserResponse
+	(*CreateAccountResponse)(nil),               // 38: pb.CreateAccountResponse
+	(*GetAccountResponse)(nil),                  // 39: pb.GetAccountResponse
+	(*ListAccountsResponse)(nil),                // 40: pb.ListAccountsResponse
+	(*CreateTransferResponse)(nil),              // 41: pb.CreateTransferResponse
+	(*ListAccountEntriesResponse)(nil),          // 42: pb.ListAccountEntriesResponse
+	(*ListAccountTransfersResponse)(nil),        // 43: pb.ListAccountTransfersResponse
+	(*RenewAccessTokenResponse)(nil),            // 44: pb.RenewAccessTokenResponse
+	(*LogoutUserResponse)(nil),                  // 45: pb.LogoutUserResponse
+	(*ListSessionsResponse)(nil),                // 46: pb.ListSessionsResponse
+	(*RevokeSessionResponse)(nil),               // 47: pb.RevokeSessionResponse
+	(*RevokeAllSessionsResponse)(nil),           // 48: pb.RevokeAllSessionsResponse
+	(*VerifyEmailResponse)(nil),                 // 49: pb.VerifyEmailResponse
+	(*DepositResponse)(nil),                     // 50: pb.DepositResponse
+	(*WithdrawResponse)(nil),                    // 51: pb.WithdrawResponse
+	(*ListCurrenciesResponse)(nil),              // 52: pb.ListCurrenciesResponse
+	(*CreateCurrencyResponse)(nil),              // 53: pb.CreateCurrencyResponse
+	(*UpdateCurrencyResponse)(nil),              // 54: pb.UpdateCurrencyResponse
+	(*CreateScheduledTransferResponse)(nil),     // 55: pb.CreateScheduledTransferResponse
+	(*GetScheduledTransferResponse)(nil),        // 56: pb.GetScheduledTransferResponse
+	(*ListScheduledTransfersResponse)(nil),      // 57: pb.ListScheduledTransfersResponse
+	(*UpdateScheduledTransferResponse)(nil),     // 58: pb.UpdateScheduledTransferResponse
+	(*DeleteScheduledTransferResponse)(nil),     // 59: pb.DeleteScheduledTransferResponse
+	(*PlaceHoldResponse)(nil),                   // 60: pb.PlaceHoldResponse
+	(*CaptureHoldResponse)(nil),                 // 61: pb.CaptureHoldResponse
+	(*ReleaseHoldResponse)(nil),                 // 62: pb.ReleaseHoldResponse
+	(*ReverseTransferResponse)(nil),             // 63: pb.ReverseTransferResponse
+	(*CompleteTransferResponse)(nil),            // 64: pb.CompleteTransferResponse
+	(*CancelTransferResponse)(nil),              // 65: pb.CancelTransferResponse
+	(*SetTransferLimitResponse)(nil),            // 66: pb.SetTransferLimitResponse
+	(*GetAccountTransferLimitsResponse)(nil),    // 67: pb.GetAccountTransferLimitsResponse
+	(*UpdateAccountOverdraftLimitResponse)(nil), // 68: pb.UpdateAccountOverdraftLimitResponse
+	(*GetTransferFeeResponse)(nil),              // 69: pb.GetTransferFeeResponse
 }
 var file_service_simple_bank_proto_depIdxs = []int32{
-	0, // 0: pb.SimpleBank.CreateUser:input_type -> pb.CreateUserRequest
-	1, // 1: pb.SimpleBank.UpdateUser:input_type -> pb.UpdateUserRequest
-	2, // 2: pb.SimpleBank.LoginUser:input_type -> pb.LoginUserRequest
-	3, // 3: pb.SimpleBank.CreateUser:output_type -> pb.CreateUserResponse
-	4, // 4: pb.SimpleBank.UpdateUser:output_type -> pb.UpdateUserResponse
-	5, // 5: pb.SimpleBank.LoginUser:output_type -> pb.LoginUserResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: pb.SimpleBank.CreateUser:input_type -> pb.CreateUserRequest
+	1,  // 1: pb.SimpleBank.UpdateUser:input_type -> pb.UpdateUserRequest
+	2,  // 2: pb.SimpleBank.LoginUser:input_type -> pb.LoginUserRequest
+	3,  // 3: pb.SimpleBank.CreateAccount:input_type -> pb.CreateAccountRequest
+	4,  // 4: pb.SimpleBank.GetAccount:input_type -> pb.GetAccountRequest
+	5,  // 5: pb.SimpleBank.ListAccounts:input_type -> pb.ListAccountsRequest
+	6,  // 6: pb.SimpleBank.CreateTransfer:input_type -> pb.CreateTransferRequest
+	7,  // 7: pb.SimpleBank.ListAccountEntries:input_type -> pb.ListAccountEntriesRequest
+	8,  // 8: pb.SimpleBank.ListAccountTransfers:input_type -> pb.ListAccountTransfersRequest
+	9,  // 9: pb.SimpleBank.RenewAccessToken:input_type -> pb.RenewAccessTokenRequest
+	10, // 10: pb.SimpleBank.LogoutUser:input_type -> pb.LogoutUserRequest
+	11, // 11: pb.SimpleBank.ListSessions:input_type -> pb.ListSessionsRequest
+	12, // 12: pb.SimpleBank.RevokeSession:input_type -> pb.RevokeSessionRequest
+	13, // 13: pb.SimpleBank.RevokeAllSessions:input_type -> pb.RevokeAllSessionsRequest
+	14, // 14: pb.SimpleBank.VerifyEmail:input_type -> pb.VerifyEmailRequest
+	15, // 15: pb.SimpleBank.Deposit:input_type -> pb.DepositRequest
+	16, // 16: pb.SimpleBank.Withdraw:input_type -> pb.WithdrawRequest
+	17, // 17: pb.SimpleBank.ListCurrencies:input_type -> pb.ListCurrenciesRequest
+	18, // 18: pb.SimpleBank.CreateCurrency:input_type -> pb.CreateCurrencyRequest
+	19, // 19: pb.SimpleBank.UpdateCurrency:input_type -> pb.UpdateCurrencyRequest
+	20, // 20: pb.SimpleBank.CreateScheduledTransfer:input_type -> pb.CreateScheduledTransferRequest
+	21, // 21: pb.SimpleBank.GetScheduledTransfer:input_type -> pb.GetScheduledTransferRequest
+	22, // 22: pb.SimpleBank.ListScheduledTransfers:input_type -> pb.ListScheduledTransfersRequest
+	23, // 23: pb.SimpleBank.UpdateScheduledTransfer:input_type -> pb.UpdateScheduledTransferRequest
+	24, // 24: pb.SimpleBank.DeleteScheduledTransfer:input_type -> pb.DeleteScheduledTransferRequest
+	25, // 25: pb.SimpleBank.PlaceHold:input_type -> pb.PlaceHoldRequest
+	26, // 26: pb.SimpleBank.CaptureHold:input_type -> pb.CaptureHoldRequest
+	27, // 27: pb.SimpleBank.ReleaseHold:input_type -> pb.ReleaseHoldRequest
+	28, // 28: pb.SimpleBank.ReverseTransfer:input_type -> pb.ReverseTransferRequest
+	29, // 29: pb.SimpleBank.CompleteTransfer:input_type -> pb.CompleteTransferRequest
+	30, // 30: pb.SimpleBank.CancelTransfer:input_type -> pb.CancelTransferRequest
+	31, // 31: pb.SimpleBank.SetTransferLimit:input_type -> pb.SetTransferLimitRequest
+	32, // 32: pb.SimpleBank.GetAccountTransferLimits:input_type -> pb.GetAccountTransferLimitsRequest
+	33, // 33: pb.SimpleBank.UpdateAccountOverdraftLimit:input_type -> pb.UpdateAccountOverdraftLimitRequest
+	34, // 34: pb.SimpleBank.GetTransferFee:input_type -> pb.GetTransferFeeRequest
+	35, // 35: pb.SimpleBank.CreateUser:output_type -> pb.CreateUserResponse
+	36, // 36: pb.SimpleBank.UpdateUser:output_type -> pb.UpdateUserResponse
+	37, // 37: pb.SimpleBank.LoginUser:output_type -> pb.LoginUserResponse
+	38, // 38: pb.SimpleBank.CreateAccount:output_type -> pb.CreateAccountResponse
+	39, // 39: pb.SimpleBank.GetAccount:output_type -> pb.GetAccountResponse
+	40, // 40: pb.SimpleBank.ListAccounts:output_type -> pb.ListAccountsResponse
+	41, // 41: pb.SimpleBank.CreateTransfer:output_type -> pb.CreateTransferResponse
+	42, // 42: pb.SimpleBank.ListAccountEntries:output_type -> pb.ListAccountEntriesResponse
+	43, // 43: pb.SimpleBank.ListAccountTransfers:output_type -> pb.ListAccountTransfersResponse
+	44, // 44: pb.SimpleBank.RenewAccessToken:output_type -> pb.RenewAccessTokenResponse
+	45, // 45: pb.SimpleBank.LogoutUser:output_type -> pb.LogoutUserResponse
+	46, // 46: pb.SimpleBank.ListSessions:output_type -> pb.ListSessionsResponse
+	47, // 47: pb.SimpleBank.RevokeSession:output_type -> pb.RevokeSessionResponse
+	48, // 48: pb.SimpleBank.RevokeAllSessions:output_type -> pb.RevokeAllSessionsResponse
+	49, // 49: pb.SimpleBank.VerifyEmail:output_type -> pb.VerifyEmailResponse
+	50, // 50: pb.SimpleBank.Deposit:output_type -> pb.DepositResponse
+	51, // 51: pb.SimpleBank.Withdraw:output_type -> pb.WithdrawResponse
+	52, // 52: pb.SimpleBank.ListCurrencies:output_type -> pb.ListCurrenciesResponse
+	53, // 53: pb.SimpleBank.CreateCurrency:output_type -> pb.CreateCurrencyResponse
+	54, // 54: pb.SimpleBank.UpdateCurrency:output_type -> pb.UpdateCurrencyResponse
+	55, // 55: pb.SimpleBank.CreateScheduledTransfer:output_type -> pb.CreateScheduledTransferResponse
+	56, // 56: pb.SimpleBank.GetScheduledTransfer:output_type -> pb.GetScheduledTransferResponse
+	57, // 57: pb.SimpleBank.ListScheduledTransfers:output_type -> pb.ListScheduledTransfersResponse
+	58, // 58: pb.SimpleBank.UpdateScheduledTransfer:output_type -> pb.UpdateScheduledTransferResponse
+	59, // 59: pb.SimpleBank.DeleteScheduledTransfer:output_type -> pb.DeleteScheduledTransferResponse
+	60, // 60: pb.SimpleBank.PlaceHold:output_type -> pb.PlaceHoldResponse
+	61, // 61: pb.SimpleBank.CaptureHold:output_type -> pb.CaptureHoldResponse
+	62, // 62: pb.SimpleBank.ReleaseHold:output_type -> pb.ReleaseHoldResponse
+	63, // 63: pb.SimpleBank.ReverseTransfer:output_type -> pb.ReverseTransferResponse
+	64, // 64: pb.SimpleBank.CompleteTransfer:output_type -> pb.CompleteTransferResponse
+	65, // 65: pb.SimpleBank.CancelTransfer:output_type -> pb.CancelTransferResponse
+	66, // 66: pb.SimpleBank.SetTransferLimit:output_type -> pb.SetTransferLimitResponse
+	67, // 67: pb.SimpleBank.GetAccountTransferLimits:output_type -> pb.GetAccountTransferLimitsResponse
+	68, // 68: pb.SimpleBank.UpdateAccountOverdraftLimit:output_type -> pb.UpdateAccountOverdraftLimitResponse
+	69, // 69: pb.SimpleBank.GetTransferFee:output_type -> pb.GetTransferFeeResponse
+	35, // [35:70] is the sub-list for method output_type
+	0,  // [0:35] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_service_simple_bank_proto_init() }
@@ -74,6 +270,38 @@ func file_service_simple_bank_proto_init() {
 	file_rpc_create_user_proto_init()
 	file_rpc_login_user_proto_init()
 	file_rpc_update_user_proto_init()
+	file_rpc_create_account_proto_init()
+	file_rpc_get_account_proto_init()
+	file_rpc_list_accounts_proto_init()
+	file_rpc_create_transfer_proto_init()
+	file_rpc_list_account_entries_proto_init()
+	file_rpc_list_account_transfers_proto_init()
+	file_rpc_renew_access_token_proto_init()
+	file_rpc_logout_user_proto_init()
+	file_rpc_list_sessions_proto_init()
+	file_rpc_revoke_session_proto_init()
+	file_rpc_revoke_all_sessions_proto_init()
+	file_rpc_verify_email_proto_init()
+	file_rpc_deposit_proto_init()
+	file_rpc_withdraw_proto_init()
+	file_rpc_list_currencies_proto_init()
+	file_rpc_create_currency_proto_init()
+	file_rpc_update_currency_proto_init()
+	file_rpc_create_scheduled_transfer_proto_init()
+	file_rpc_get_scheduled_transfer_proto_init()
+	file_rpc_list_scheduled_transfers_proto_init()
+	file_rpc_update_scheduled_transfer_proto_init()
+	file_rpc_delete_scheduled_transfer_proto_init()
+	file_rpc_place_hold_proto_init()
+	file_rpc_capture_hold_proto_init()
+	file_rpc_release_hold_proto_init()
+	file_rpc_reverse_transfer_proto_init()
+	file_rpc_complete_transfer_proto_init()
+	file_rpc_cancel_transfer_proto_init()
+	file_rpc_set_transfer_limit_proto_init()
+	file_rpc_get_account_transfer_limits_proto_init()
+	file_rpc_update_account_overdraft_limit_proto_init()
+	file_rpc_get_transfer_fee_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -0,0 +1,246 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v6.32.0
+// source: transfer_limit.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Outgoing limits set for a currency, or for one account when account_id is set.
+// A limit that isn't set falls back to the currency, then to the defaults of the server.
+type TransferLimit struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Currency       string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	AccountId      *int64                 `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	PerTransaction *int64                 `protobuf:"varint,3,opt,name=per_transaction,json=perTransaction,proto3,oneof" json:"per_transaction,omitempty"`
+	Daily          *int64                 `protobuf:"varint,4,opt,name=daily,proto3,oneof" json:"daily,omitempty"`
+	Monthly        *int64                 `protobuf:"varint,5,opt,name=monthly,proto3,oneof" json:"monthly,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransferLimit) Reset() {
+	*x = TransferLimit{}
+	mi := &file_transfer_limit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLimit) ProtoMessage() {}
+
+func (x *TransferLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_transfer_limit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLimit.ProtoReflect.Descriptor instead.
+func (*TransferLimit) Descriptor() ([]byte, []int) {
+	return file_transfer_limit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TransferLimit) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TransferLimit) GetAccountId() int64 {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return 0
+}
+
+func (x *TransferLimit) GetPerTransaction() int64 {
+	if x != nil && x.PerTransaction != nil {
+		return *x.PerTransaction
+	}
+	return 0
+}
+
+func (x *TransferLimit) GetDaily() int64 {
+	if x != nil && x.Daily != nil {
+		return *x.Daily
+	}
+	return 0
+}
+
+func (x *TransferLimit) GetMonthly() int64 {
+	if x != nil && x.Monthly != nil {
+		return *x.Monthly
+	}
+	return 0
+}
+
+func (x *TransferLimit) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// The outgoing limits that apply to an account, in its minor units, 0 means no limit
+type TransferLimits struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PerTransaction int64                  `protobuf:"varint,1,opt,name=per_transaction,json=perTransaction,proto3" json:"per_transaction,omitempty"`
+	Daily          int64                  `protobuf:"varint,2,opt,name=daily,proto3" json:"daily,omitempty"`
+	Monthly        int64                  `protobuf:"varint,3,opt,name=monthly,proto3" json:"monthly,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransferLimits) Reset() {
+	*x = TransferLimits{}
+	mi := &file_transfer_limit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLimits) ProtoMessage() {}
+
+func (x *TransferLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_transfer_limit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLimits.ProtoReflect.Descriptor instead.
+func (*TransferLimits) Descriptor() ([]byte, []int) {
+	return file_transfer_limit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TransferLimits) GetPerTransaction() int64 {
+	if x != nil {
+		return x.PerTransaction
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetDaily() int64 {
+	if x != nil {
+		return x.Daily
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetMonthly() int64 {
+	if x != nil {
+		return x.Monthly
+	}
+	return 0
+}
+
+var File_transfer_limit_proto protoreflect.FileDescriptor
+
+const file_transfer_limit_proto_rawDesc = "" +
+	"\n" +
+	"\x14transfer_limit.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xab\x02\n" +
+	"\rTransferLimit\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\"\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\x03H\x00R\taccountId\x88\x01\x01\x12,\n" +
+	"\x0fper_transaction\x18\x03 \x01(\x03H\x01R\x0eperTransaction\x88\x01\x01\x12\x19\n" +
+	"\x05daily\x18\x04 \x01(\x03H\x02R\x05daily\x88\x01\x01\x12\x1d\n" +
+	"\amonthly\x18\x05 \x01(\x03H\x03R\amonthly\x88\x01\x01\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\r\n" +
+	"\v_account_idB\x12\n" +
+	"\x10_per_transactionB\b\n" +
+	"\x06_dailyB\n" +
+	"\n" +
+	"\b_monthly\"i\n" +
+	"\x0eTransferLimits\x12'\n" +
+	"\x0fper_transaction\x18\x01 \x01(\x03R\x0eperTransaction\x12\x14\n" +
+	"\x05daily\x18\x02 \x01(\x03R\x05daily\x12\x18\n" +
+	"\amonthly\x18\x03 \x01(\x03R\amonthlyB\x10Z\x0esimple-bank/pbb\x06proto3"
+
+var (
+	file_transfer_limit_proto_rawDescOnce sync.Once
+	file_transfer_limit_proto_rawDescData []byte
+)
+
+func file_transfer_limit_proto_rawDescGZIP() []byte {
+	file_transfer_limit_proto_rawDescOnce.Do(func() {
+		file_transfer_limit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_transfer_limit_proto_rawDesc), len(file_transfer_limit_proto_rawDesc)))
+	})
+	return file_transfer_limit_proto_rawDescData
+}
+
+var file_transfer_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_transfer_limit_proto_goTypes = []any{
+	(*TransferLimit)(nil),         // 0: pb.TransferLimit
+	(*TransferLimits)(nil),        // 1: pb.TransferLimits
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_transfer_limit_proto_depIdxs = []int32{
+	2, // 0: pb.TransferLimit.updated_at:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_transfer_limit_proto_init() }
+func file_transfer_limit_proto_init() {
+	if File_transfer_limit_proto != nil {
+		return
+	}
+	file_transfer_limit_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transfer_limit_proto_rawDesc), len(file_transfer_limit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_transfer_limit_proto_goTypes,
+		DependencyIndexes: file_transfer_limit_proto_depIdxs,
+		MessageInfos:      file_transfer_limit_proto_msgTypes,
+	}.Build()
+	File_transfer_limit_proto = out.File
+	file_transfer_limit_proto_goTypes = nil
+	file_transfer_limit_proto_depIdxs = nil
+}
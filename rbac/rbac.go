@@ -0,0 +1,72 @@
+package rbac
+
+import "errors"
+
+// Roles stored in `users.role` and carried by the access token
+const (
+	DepositorRole = "depositor"
+	BankerRole    = "banker"
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// An action that goes beyond the ownership rules of a depositor
+type Permission string
+
+const (
+	// View the accounts, entries and transfers of any user
+	ViewAnyAccount Permission = "view_any_account"
+	// Update the profile of any user
+	UpdateAnyUser Permission = "update_any_user"
+	// Deposit cash into and withdraw cash from any account
+	MoveCash Permission = "move_cash"
+	// Add currencies and enable or disable them
+	ManageCurrencies Permission = "manage_currencies"
+	// Capture and release the holds on any account
+	ManageHolds Permission = "manage_holds"
+	// Refund any transfer
+	ReverseTransfers Permission = "reverse_transfers"
+	// Set the transfer limits of currencies and accounts
+	ManageLimits Permission = "manage_limits"
+	// Set how far any account may be overdrawn
+	ManageOverdrafts Permission = "manage_overdrafts"
+	// Open accounts of a type other than personal, the type decides which fees apply
+	SetAccountType Permission = "set_account_type"
+	// Open accounts for any user
+	OpenAnyAccount Permission = "open_any_account"
+)
+
+var rolePermissions = map[string]map[Permission]bool{
+	// Depositors can only act on what they own
+	DepositorRole: {},
+	BankerRole: {
+		ViewAnyAccount:   true,
+		UpdateAnyUser:    true,
+		MoveCash:         true,
+		ManageCurrencies: true,
+		ManageHolds:      true,
+		ReverseTransfers: true,
+		ManageLimits:     true,
+		ManageOverdrafts: true,
+		SetAccountType:   true,
+		OpenAnyAccount:   true,
+	},
+}
+
+func IsSupportedRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// Reports whether the role is granted the permission
+func Can(role string, permission Permission) bool {
+	return rolePermissions[role][permission]
+}
+
+// Returns `ErrPermissionDenied` unless the role is granted the permission
+func Authorize(role string, permission Permission) error {
+	if !Can(role, permission) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSupportedRole(t *testing.T) {
+	require.True(t, IsSupportedRole(DepositorRole))
+	require.True(t, IsSupportedRole(BankerRole))
+	require.False(t, IsSupportedRole(""))
+	require.False(t, IsSupportedRole("admin"))
+}
+
+func TestCan(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		permission Permission
+		want       bool
+	}{
+		{"banker views any account", BankerRole, ViewAnyAccount, true},
+		{"banker updates any user", BankerRole, UpdateAnyUser, true},
+		{"depositor views any account", DepositorRole, ViewAnyAccount, false},
+		{"banker moves cash", BankerRole, MoveCash, true},
+		{"depositor updates any user", DepositorRole, UpdateAnyUser, false},
+		{"depositor moves cash", DepositorRole, MoveCash, false},
+		{"banker manages currencies", BankerRole, ManageCurrencies, true},
+		{"depositor manages currencies", DepositorRole, ManageCurrencies, false},
+		{"banker manages holds", BankerRole, ManageHolds, true},
+		{"depositor manages holds", DepositorRole, ManageHolds, false},
+		{"banker reverses transfers", BankerRole, ReverseTransfers, true},
+		{"depositor reverses transfers", DepositorRole, ReverseTransfers, false},
+		{"banker manages limits", BankerRole, ManageLimits, true},
+		{"depositor manages limits", DepositorRole, ManageLimits, false},
+		{"banker manages overdrafts", BankerRole, ManageOverdrafts, true},
+		{"depositor manages overdrafts", DepositorRole, ManageOverdrafts, false},
+		{"banker sets account type", BankerRole, SetAccountType, true},
+		{"depositor sets account type", DepositorRole, SetAccountType, false},
+		{"banker opens any account", BankerRole, OpenAnyAccount, true},
+		{"depositor opens any account", DepositorRole, OpenAnyAccount, false},
+		{"unknown role", "admin", ViewAnyAccount, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Can(tt.role, tt.permission))
+
+			err := Authorize(tt.role, tt.permission)
+			if tt.want {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrPermissionDenied)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How far ahead a cron expression is searched, a year has every month and weekday combination
+// and leap days come back within 8 years
+const searchLimit = 8 * 366 * 24 * time.Hour
+
+var ErrNeverFires = errors.New("schedule never fires")
+
+type Schedule interface {
+	// Returns the first occurrence strictly after `t`
+	Next(t time.Time) time.Time
+}
+
+// Fires every `every` since `start`, so the occurrences keep their phase however late `Next` is called
+type intervalSchedule struct {
+	start time.Time
+	every time.Duration
+}
+
+func NewInterval(start time.Time, every time.Duration) (Schedule, error) {
+	if every <= 0 {
+		return nil, fmt.Errorf("interval must be positive: %v", every)
+	}
+	return &intervalSchedule{start: start, every: every}, nil
+}
+
+func (schedule *intervalSchedule) Next(t time.Time) time.Time {
+	if t.Before(schedule.start) {
+		return schedule.start
+	}
+
+	n := t.Sub(schedule.start)/schedule.every + 1
+	return schedule.start.Add(n * schedule.every)
+}
+
+// A standard 5 field cron expression: minute, hour, day of month, month and day of week.
+// Fields support `*`, numbers, ranges, lists and steps, eg: `0 9 1 * *` is 09:00 on the 1st of every month.
+// Times are in UTC.
+type cronSchedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// Cron matches either day field when both are restricted, and only the restricted one otherwise
+	dayOfMonthAny bool
+	dayOfWeekAny  bool
+}
+
+type field struct {
+	name string
+	min  int
+	max  int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	// 7 is also Sunday
+	{"day of week", 0, 7},
+}
+
+func ParseCron(expression string) (Schedule, error) {
+	parts := strings.Fields(expression)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("cron expression must have %d fields: %q", len(fields), expression)
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	// Sunday is both 0 and 7
+	if sets[4]&(1<<7) != 0 {
+		sets[4] = sets[4]&^(1<<7) | 1
+	}
+
+	schedule := &cronSchedule{
+		minute:        sets[0],
+		hour:          sets[1],
+		dayOfMonth:    sets[2],
+		month:         sets[3],
+		dayOfWeek:     sets[4],
+		dayOfMonthAny: parts[2] == "*",
+		dayOfWeekAny:  parts[4] == "*",
+	}
+
+	// Eg: `0 0 30 2 *` asks for February 30th
+	if schedule.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("%w: %q", ErrNeverFires, expression)
+	}
+
+	return schedule, nil
+}
+
+// Parses a comma separated list of `*`, `n`, `a-b`, each optionally followed by `/step`
+func parseField(value string, f field) (uint64, error) {
+	var set uint64
+
+	for _, item := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid %s step: %q", f.name, item)
+			}
+		}
+
+		low, high := f.min, f.max
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			low, err = strconv.Atoi(lowPart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s: %q", f.name, item)
+			}
+			high = low
+			if isRange {
+				high, err = strconv.Atoi(highPart)
+				if err != nil {
+					return 0, fmt.Errorf("invalid %s: %q", f.name, item)
+				}
+			} else if hasStep {
+				// `n/step` means from n to the end of the field
+				high = f.max
+			}
+		}
+
+		if low < f.min || high > f.max || low > high {
+			return 0, fmt.Errorf("%s must be between %d and %d: %q", f.name, f.min, f.max, item)
+		}
+
+		for i := low; i <= high; i += step {
+			set |= 1 << i
+		}
+	}
+
+	return set, nil
+}
+
+func (schedule *cronSchedule) Next(t time.Time) time.Time {
+	// Cron has minute precision, the next candidate is the start of the next minute
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+
+	for day := t.Truncate(24 * time.Hour); day.Before(limit); day = day.AddDate(0, 0, 1) {
+		if !schedule.matchesDay(day) {
+			continue
+		}
+
+		// Only the first day can start in the middle
+		from := 0
+		if day.Before(t) {
+			from = t.Hour()*60 + t.Minute()
+		}
+
+		for minuteOfDay := from; minuteOfDay < 24*60; {
+			hour, minute := minuteOfDay/60, minuteOfDay%60
+			if schedule.hour&(1<<hour) == 0 {
+				minuteOfDay = (hour + 1) * 60
+				continue
+			}
+			if schedule.minute&(1<<minute) != 0 {
+				return day.Add(time.Duration(minuteOfDay) * time.Minute)
+			}
+			minuteOfDay++
+		}
+	}
+
+	return time.Time{}
+}
+
+func (schedule *cronSchedule) matchesDay(day time.Time) bool {
+	if schedule.month&(1<<int(day.Month())) == 0 {
+		return false
+	}
+
+	dayOfMonth := schedule.dayOfMonth&(1<<day.Day()) != 0
+	dayOfWeek := schedule.dayOfWeek&(1<<int(day.Weekday())) != 0
+
+	switch {
+	case schedule.dayOfMonthAny && schedule.dayOfWeekAny:
+		return true
+	case schedule.dayOfMonthAny:
+		return dayOfWeek
+	case schedule.dayOfWeekAny:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
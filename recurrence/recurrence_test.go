@@ -0,0 +1,78 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		after      time.Time
+		want       time.Time
+	}{
+		{"EveryMinute", "* * * * *", date(2024, 1, 1, 10, 0).Add(30 * time.Second), date(2024, 1, 1, 10, 1)},
+		{"StrictlyAfter", "0 9 * * *", date(2024, 1, 1, 9, 0), date(2024, 1, 2, 9, 0)},
+		{"LaterToday", "30 14 * * *", date(2024, 1, 1, 9, 0), date(2024, 1, 1, 14, 30)},
+		{"FirstOfMonth", "0 9 1 * *", date(2024, 1, 15, 0, 0), date(2024, 2, 1, 9, 0)},
+		{"EndOfYear", "0 0 1 1 *", date(2024, 12, 31, 23, 59), date(2025, 1, 1, 0, 0)},
+		{"LeapDay", "0 0 29 2 *", date(2024, 3, 1, 0, 0), date(2028, 2, 29, 0, 0)},
+		{"Weekdays", "0 9 * * 1-5", date(2024, 1, 5, 10, 0), date(2024, 1, 8, 9, 0)},
+		{"SundayAsSeven", "0 0 * * 7", date(2024, 1, 1, 0, 0), date(2024, 1, 7, 0, 0)},
+		{"Steps", "*/15 * * * *", date(2024, 1, 1, 10, 16), date(2024, 1, 1, 10, 30)},
+		{"List", "0 8,20 * * *", date(2024, 1, 1, 9, 0), date(2024, 1, 1, 20, 0)},
+		// Both day fields restricted, either one matches
+		{"DayOfMonthOrWeek", "0 0 15 * 1", date(2024, 1, 2, 0, 0), date(2024, 1, 8, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expression)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, schedule.Next(tt.after))
+		})
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{"TooFewFields", "0 9 * *"},
+		{"TooManyFields", "0 9 * * * *"},
+		{"OutOfRange", "60 9 * * *"},
+		{"ReversedRange", "0 17-9 * * *"},
+		{"ZeroStep", "*/0 * * * *"},
+		{"NotANumber", "a 9 * * *"},
+		{"NeverFires", "0 0 30 2 *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCron(tt.expression)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestIntervalNext(t *testing.T) {
+	start := date(2024, 1, 1, 10, 0)
+	schedule, err := NewInterval(start, time.Hour)
+	require.NoError(t, err)
+
+	require.Equal(t, start, schedule.Next(start.Add(-time.Minute)))
+	require.Equal(t, start.Add(time.Hour), schedule.Next(start))
+	// Late calls keep the phase of the start time
+	require.Equal(t, start.Add(3*time.Hour), schedule.Next(start.Add(2*time.Hour+time.Minute)))
+
+	_, err = NewInterval(start, 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package sessioncache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "simple-bank/db/sqlc"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionBlocked  = errors.New("session is blocked")
+	ErrSessionExpired  = errors.New("session has expired")
+)
+
+// Expired entries are only swept once the cache grows past this size
+const sweepThreshold = 10_000
+
+// Reads a session by id, satisfied by `db.Store`
+type SessionGetter interface {
+	GetSession(ctx context.Context, id uuid.UUID) (db.Session, error)
+}
+
+type entry struct {
+	session  db.Session
+	cachedAt time.Time
+}
+
+// Checks that the session of an access token is still active.
+// Sessions are cached in memory for `ttl`, so a session blocked by another
+// server instance is only noticed once its entry is stale.
+type Cache struct {
+	store   SessionGetter
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[uuid.UUID]entry
+}
+
+func New(store SessionGetter, ttl time.Duration) *Cache {
+	return &Cache{
+		store:   store,
+		ttl:     ttl,
+		entries: make(map[uuid.UUID]entry),
+	}
+}
+
+// Returns an error if the session is missing, blocked or expired
+func (cache *Cache) Check(ctx context.Context, sessionID uuid.UUID) error {
+	session, err := cache.get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.IsBlocked {
+		return ErrSessionBlocked
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return ErrSessionExpired
+	}
+
+	return nil
+}
+
+// Drops a session from the cache, eg: after it was blocked by this server
+func (cache *Cache) Invalidate(sessionID uuid.UUID) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	delete(cache.entries, sessionID)
+}
+
+// Drops every session of a user from the cache
+func (cache *Cache) InvalidateUser(username string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for id, entry := range cache.entries {
+		if entry.session.Username == username {
+			delete(cache.entries, id)
+		}
+	}
+}
+
+func (cache *Cache) get(ctx context.Context, sessionID uuid.UUID) (db.Session, error) {
+	cache.mu.Lock()
+	entry, ok := cache.entries[sessionID]
+	cache.mu.Unlock()
+
+	if ok && time.Since(entry.cachedAt) < cache.ttl {
+		return entry.session, nil
+	}
+
+	session, err := cache.store.GetSession(ctx, sessionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return session, ErrSessionNotFound
+		}
+		return session, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	cache.set(session)
+	return session, nil
+}
+
+func (cache *Cache) set(session db.Session) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := time.Now()
+	if len(cache.entries) >= sweepThreshold {
+		for id, entry := range cache.entries {
+			if now.Sub(entry.cachedAt) >= cache.ttl {
+				delete(cache.entries, id)
+			}
+		}
+	}
+
+	cache.entries[session.ID] = entry{session: session, cachedAt: now}
+}
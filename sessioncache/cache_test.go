@@ -0,0 +1,135 @@
+package sessioncache
+
+import (
+	"context"
+	"database/sql"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func randomSession(username string) db.Session {
+	return db.Session{
+		ID:        uuid.New(),
+		Username:  username,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func TestCheckActiveSessionIsCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	session := randomSession(util.RandomOwner())
+	store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+
+	cache := New(store, time.Minute)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, cache.Check(context.Background(), session.ID))
+	}
+}
+
+func TestCheckStaleEntryIsReloaded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	session := randomSession(util.RandomOwner())
+	blockedSession := session
+	blockedSession.IsBlocked = true
+
+	gomock.InOrder(
+		store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil),
+		store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(blockedSession, nil),
+	)
+
+	// Every entry is stale right away
+	cache := New(store, 0)
+	require.NoError(t, cache.Check(context.Background(), session.ID))
+	require.ErrorIs(t, cache.Check(context.Background(), session.ID), ErrSessionBlocked)
+}
+
+func TestCheckInactiveSession(t *testing.T) {
+	username := util.RandomOwner()
+
+	blockedSession := randomSession(username)
+	blockedSession.IsBlocked = true
+
+	expiredSession := randomSession(username)
+	expiredSession.ExpiresAt = time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name       string
+		sessionID  uuid.UUID
+		buildStubs func(store *mockdb.MockStore)
+		wantErr    error
+	}{
+		{
+			name:      "Blocked",
+			sessionID: blockedSession.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(1).Return(blockedSession, nil)
+			},
+			wantErr: ErrSessionBlocked,
+		},
+		{
+			name:      "Expired",
+			sessionID: expiredSession.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(1).Return(expiredSession, nil)
+			},
+			wantErr: ErrSessionExpired,
+		},
+		{
+			name:      "NotFound",
+			sessionID: uuid.New(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(1).Return(db.Session{}, sql.ErrNoRows)
+			},
+			wantErr: ErrSessionNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			cache := New(store, time.Minute)
+			err := cache.Check(context.Background(), tc.sessionID)
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	username := util.RandomOwner()
+	session1 := randomSession(username)
+	session2 := randomSession(username)
+
+	store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session1.ID)).Times(3).Return(session1, nil)
+	store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session2.ID)).Times(2).Return(session2, nil)
+
+	cache := New(store, time.Minute)
+	require.NoError(t, cache.Check(context.Background(), session1.ID))
+	require.NoError(t, cache.Check(context.Background(), session2.ID))
+
+	cache.Invalidate(session1.ID)
+	require.NoError(t, cache.Check(context.Background(), session1.ID))
+
+	cache.InvalidateUser(username)
+	require.NoError(t, cache.Check(context.Background(), session1.ID))
+	require.NoError(t, cache.Check(context.Background(), session2.ID))
+}
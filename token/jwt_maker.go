@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/dgrijalva/jwt-go"
 )
 
@@ -26,9 +28,9 @@ func NewJwtMaker(secretKey string) (Maker, error) {
 	return maker, nil
 }
 
-// Creates token for a specific username and duration
-func (maker *JWTMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, duration)
+// Creates token for a specific username, role, session and duration
+func (maker *JWTMaker) CreateToken(username string, role string, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewPayload(username, role, sessionID, duration)
 	if err != nil {
 		return "", nil, err
 	}
@@ -1,11 +1,15 @@
 package token
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // Interface for managing tokens
 type Maker interface {
-	// Creates token for a specific username and duration
-	CreateToken(username string, duration time.Duration) (string, *Payload, error)
+	// Creates token for a specific username, role, session and duration
+	CreateToken(username string, role string, sessionID uuid.UUID, duration time.Duration) (string, *Payload, error)
 	// Checks if the token is valid
 	VerifyToken(token string) (*Payload, error)
 }
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -9,15 +11,25 @@ import (
 // Store all configuration of the application.
 // Values are read by viper from a config file or `env` variables.
 type Config struct {
-	DBDriver             string        `mapstructure:"DB_DRIVER"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	HTTPServerAddress    string        `mapstructure:"HTTP_SERVER_ADDRESS"`
-	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
-	GRPCServerAddress    string        `mapstructure:"GRPC_SERVER_ADDRESS"`
-	MigrationURL         string        `mapstructure:"MIGRATION_URL"`
-	Environment          string        `mapstructure:"ENVIRONMENT"`
+	DBDriver                    string        `mapstructure:"DB_DRIVER"`
+	DBSource                    string        `mapstructure:"DB_SOURCE"`
+	HTTPServerAddress           string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	TokenSymmetricKey           string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration         time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration        time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	SessionCacheTTL             time.Duration `mapstructure:"SESSION_CACHE_TTL"`
+	GRPCServerAddress           string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	MigrationURL                string        `mapstructure:"MIGRATION_URL"`
+	Environment                 string        `mapstructure:"ENVIRONMENT"`
+	EmailSenderName             string        `mapstructure:"EMAIL_SENDER_NAME"`
+	EmailSenderAddress          string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	MailOutboxDir               string        `mapstructure:"MAIL_OUTBOX_DIR"`
+	VerifyEmailURL              string        `mapstructure:"VERIFY_EMAIL_URL"`
+	DefaultAccountCurrencies    []string      `mapstructure:"DEFAULT_ACCOUNT_CURRENCIES"`
+	FXRatesFile                 string        `mapstructure:"FX_RATES_FILE"`
+	TransferLimitPerTransaction int64         `mapstructure:"TRANSFER_LIMIT_PER_TRANSACTION"`
+	TransferLimitDaily          int64         `mapstructure:"TRANSFER_LIMIT_DAILY"`
+	TransferLimitMonthly        int64         `mapstructure:"TRANSFER_LIMIT_MONTHLY"`
 }
 
 // Read configurations from file or `env` variables
@@ -39,5 +51,37 @@ func LoadConfig(path string) (config Config, err error) {
 		return
 	}
 
+	config.DefaultAccountCurrencies, err = normalizeCurrencies(config.DefaultAccountCurrencies)
+	if err != nil {
+		return
+	}
+
+	// 0 means no limit
+	if config.TransferLimitPerTransaction < 0 || config.TransferLimitDaily < 0 || config.TransferLimitMonthly < 0 {
+		err = fmt.Errorf("transfer limits cannot be negative")
+		return
+	}
+
 	return
 }
+
+// Upper-cases and de-duplicates the currencies, an owner can only have one account per currency
+func normalizeCurrencies(currencies []string) ([]string, error) {
+	seen := make(map[string]bool, len(currencies))
+	normalized := make([]string, 0, len(currencies))
+
+	for _, currency := range currencies {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if currency == "" || seen[currency] {
+			continue
+		}
+		// Whether the currency is enabled is only known once the currencies are loaded from the db
+		if !IsCurrencyCode(currency) {
+			return nil, fmt.Errorf("invalid default account currency: %s", currency)
+		}
+		seen[currency] = true
+		normalized = append(normalized, currency)
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCurrencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   []string
+		want    []string
+		wantErr bool
+	}{
+		{"Empty", nil, []string{}, false},
+		{"Valid", []string{USD, EUR}, []string{USD, EUR}, false},
+		{"LowerCaseAndSpaces", []string{" usd", "eur "}, []string{USD, EUR}, false},
+		{"Duplicates", []string{USD, "usd", EUR, USD}, []string{USD, EUR}, false},
+		{"SkipsBlank", []string{"", USD, " "}, []string{USD}, false},
+		{"NotEnabledYet", []string{USD, "GBP"}, []string{USD, "GBP"}, false},
+		{"InvalidCode", []string{USD, "US$"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeCurrencies(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadConfigDefaultAccountCurrencies(t *testing.T) {
+	t.Setenv("DEFAULT_ACCOUNT_CURRENCIES", "cad,INR,CAD")
+
+	config, err := LoadConfig("..")
+	require.NoError(t, err)
+	require.Equal(t, []string{CAD, INR}, config.DefaultAccountCurrencies)
+}
+
+func TestLoadConfigTransferLimits(t *testing.T) {
+	t.Setenv("TRANSFER_LIMIT_DAILY", "500")
+
+	config, err := LoadConfig("..")
+	require.NoError(t, err)
+	require.Equal(t, int64(500), config.TransferLimitDaily)
+	require.Positive(t, config.TransferLimitPerTransaction)
+	require.Positive(t, config.TransferLimitMonthly)
+
+	t.Setenv("TRANSFER_LIMIT_MONTHLY", "-1")
+
+	_, err = LoadConfig("..")
+	require.Error(t, err)
+}
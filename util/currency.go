@@ -1,5 +1,10 @@
 package util
 
+import (
+	"regexp"
+	"sync"
+)
+
 var (
 	USD = "USD"
 	EUR = "EUR"
@@ -7,6 +12,52 @@ var (
 	CAD = "CAD"
 )
 
+// ISO 4217 alphabetic codes are three upper case letters
+var IsCurrencyCode = regexp.MustCompile(`^[A-Z]{3}$`).MatchString
+
+// An ISO 4217 currency, amounts are stored as integers in its minor units
+type Currency struct {
+	Code       string
+	MinorUnits int32
+	Enabled    bool
+}
+
+// The currencies the app knows about, replaced from the `currencies` table at startup.
+// Until then only the built-in currencies are supported.
+var registry = struct {
+	sync.RWMutex
+	byCode map[string]Currency
+}{
+	byCode: map[string]Currency{
+		USD: {Code: USD, MinorUnits: 2, Enabled: true},
+		EUR: {Code: EUR, MinorUnits: 2, Enabled: true},
+		INR: {Code: INR, MinorUnits: 2, Enabled: true},
+		CAD: {Code: CAD, MinorUnits: 2, Enabled: true},
+	},
+}
+
+// Replaces every known currency, so disabled and removed currencies stop being supported
+func SetCurrencies(currencies []Currency) {
+	byCode := make(map[string]Currency, len(currencies))
+	for _, currency := range currencies {
+		byCode[currency.Code] = currency
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	registry.byCode = byCode
+}
+
+// Returns the currency even if it is disabled
+func LookupCurrency(code string) (Currency, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	currency, ok := registry.byCode[code]
+	return currency, ok
+}
+
+// Only enabled currencies can be used for new accounts, transfers and rates
 func IsSupportedCurrency(currency string) bool {
-	return currency == USD || currency == EUR || currency == INR || currency == CAD
+	c, ok := LookupCurrency(currency)
+	return ok && c.Enabled
 }
@@ -0,0 +1,32 @@
+package util
+
+import (
+	"fmt"
+	"math"
+)
+
+// An amount in the minor units of its currency, e.g. 1050 USD is 10.50 USD.
+// Amounts travel as minor units over the API, this is how they are shown in messages.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// Formats the amount with the minor units of its currency, e.g. "10.50 USD"
+func (money Money) String() string {
+	currency, ok := LookupCurrency(money.Currency)
+	if !ok || currency.MinorUnits == 0 {
+		return fmt.Sprintf("%d %s", money.Amount, money.Currency)
+	}
+
+	sign := ""
+	amount := uint64(money.Amount)
+	if money.Amount < 0 {
+		sign = "-"
+		// Also correct for math.MinInt64, which has no positive int64
+		amount = uint64(-(money.Amount + 1)) + 1
+	}
+
+	unit := uint64(math.Pow10(int(currency.MinorUnits)))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/unit, currency.MinorUnits, amount%unit, money.Currency)
+}
@@ -0,0 +1,60 @@
+package util
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Registers a currency without minor units next to the built-in ones
+func setTestCurrencies(t *testing.T) {
+	SetCurrencies([]Currency{
+		{Code: USD, MinorUnits: 2, Enabled: true},
+		{Code: "JPY", MinorUnits: 0, Enabled: true},
+		{Code: "BHD", MinorUnits: 3, Enabled: false},
+	})
+	t.Cleanup(func() {
+		SetCurrencies([]Currency{
+			{Code: USD, MinorUnits: 2, Enabled: true},
+			{Code: EUR, MinorUnits: 2, Enabled: true},
+			{Code: INR, MinorUnits: 2, Enabled: true},
+			{Code: CAD, MinorUnits: 2, Enabled: true},
+		})
+	})
+}
+
+func TestMoneyString(t *testing.T) {
+	setTestCurrencies(t)
+
+	tests := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{"Cents", Money{1050, USD}, "10.50 USD"},
+		{"LessThanOne", Money{5, USD}, "0.05 USD"},
+		{"Negative", Money{-1050, USD}, "-10.50 USD"},
+		{"MinInt64", Money{math.MinInt64, USD}, "-92233720368547758.08 USD"},
+		{"NoMinorUnits", Money{1500, "JPY"}, "1500 JPY"},
+		{"ThreeMinorUnits", Money{1005, "BHD"}, "1.005 BHD"},
+		{"UnknownCurrency", Money{1050, EUR}, "1050 EUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.money.String())
+		})
+	}
+}
+
+func TestIsSupportedCurrency(t *testing.T) {
+	setTestCurrencies(t)
+
+	require.True(t, IsSupportedCurrency(USD))
+	require.True(t, IsSupportedCurrency("JPY"))
+	// Known but disabled
+	require.False(t, IsSupportedCurrency("BHD"))
+	// Removed by `SetCurrencies`
+	require.False(t, IsSupportedCurrency(EUR))
+}
@@ -4,6 +4,11 @@ import (
 	"fmt"
 	"net/mail"
 	"regexp"
+	"simple-bank/pagination"
+	"simple-bank/recurrence"
+	"simple-bank/util"
+
+	"github.com/google/uuid"
 )
 
 var (
@@ -11,6 +16,17 @@ var (
 	isValidFullName = regexp.MustCompile(`^[a-zA-Z\s]+$`).MatchString
 )
 
+// Owners of the bank's own accounts, created by the migrations, see `db.SystemUsername` and `db.FeesUsername`
+var reservedUsernames = map[string]bool{
+	"system": true,
+	"fees":   true,
+}
+
+// Reports whether the username belongs to the bank, so nobody can sign up with it
+func IsReservedUsername(value string) bool {
+	return reservedUsernames[value]
+}
+
 func ValidateString(value string, minLength int, maxLength int) error {
 	n := len(value)
 	if n < minLength || n > maxLength {
@@ -26,6 +42,9 @@ func ValidateUsername(value string) error {
 	if !isValidUsername(value) {
 		return fmt.Errorf("must contain only lowercase letters, digits, or underscore")
 	}
+	if IsReservedUsername(value) {
+		return fmt.Errorf("is reserved")
+	}
 	return nil
 }
 
@@ -53,6 +72,10 @@ func ValidateEmail(value string) error {
 	return nil
 }
 
+func ValidateToken(value string) error {
+	return ValidateString(value, 1, 1024)
+}
+
 func ValidateEmailId(value int64) error {
 	if value <= 0 {
 		return fmt.Errorf("must be a positive integer")
@@ -63,3 +86,120 @@ func ValidateEmailId(value int64) error {
 func ValidateSecretCode(value string) error {
 	return ValidateString(value, 32, 128)
 }
+
+func ValidateAccountId(value int64) error {
+	return ValidateId(value)
+}
+
+// Validates the id of a row other than an account, eg: a transfer, a hold or a scheduled transfer
+func ValidateId(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+func ValidateAmount(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+// An overdraft limit of 0 means the account can't be overdrawn
+func ValidateOverdraftLimit(value int64) error {
+	if value < 0 {
+		return fmt.Errorf("must be a non-negative integer")
+	}
+	return nil
+}
+
+// A limit of 0 turns the limit off
+func ValidateTransferLimit(value int64) error {
+	if value < 0 {
+		return fmt.Errorf("must be a non-negative integer")
+	}
+	return nil
+}
+
+func ValidateCurrency(value string) error {
+	if !util.IsSupportedCurrency(value) {
+		return fmt.Errorf("unsupported currency")
+	}
+	return nil
+}
+
+func ValidateCurrencyCode(value string) error {
+	if !util.IsCurrencyCode(value) {
+		return fmt.Errorf("must be an ISO 4217 code of three upper case letters")
+	}
+	return nil
+}
+
+func ValidateMinorUnits(value int32) error {
+	if value < 0 || value > 4 {
+		return fmt.Errorf("must be between 0 and 4")
+	}
+	return nil
+}
+
+func ValidateCronExpression(value string) error {
+	_, err := recurrence.ParseCron(value)
+	return err
+}
+
+// Recurring transfers run at most once a minute, like cron expressions
+func ValidateIntervalSeconds(value int64) error {
+	if value < 60 {
+		return fmt.Errorf("must be at least 60")
+	}
+	return nil
+}
+
+// A hold reserves funds for at least a minute and at most 30 days
+func ValidateHoldExpiresInSeconds(value int64) error {
+	if value < 60 || value > 30*24*60*60 {
+		return fmt.Errorf("must be between 60 and %d", 30*24*60*60)
+	}
+	return nil
+}
+
+func ValidatePageSize(value int32) error {
+	if value < 1 || value > pagination.MaxPageSize {
+		return fmt.Errorf("must be between 1 and %d", pagination.MaxPageSize)
+	}
+	return nil
+}
+
+func ValidateIdempotencyKey(value string) error {
+	return ValidateString(value, 8, 255)
+}
+
+func ValidateDirection(value string) error {
+	if value != "incoming" && value != "outgoing" {
+		return fmt.Errorf("must be either incoming or outgoing")
+	}
+	return nil
+}
+
+func ValidateAccountType(value string) error {
+	if value != "personal" && value != "business" {
+		return fmt.Errorf("must be either personal or business")
+	}
+	return nil
+}
+
+func ValidateTransferStatus(value string) error {
+	switch value {
+	case "pending", "completed", "failed", "reversed", "cancelled":
+		return nil
+	}
+	return fmt.Errorf("must be one of pending, completed, failed, reversed or cancelled")
+}
+
+func ValidateSessionId(value string) error {
+	if _, err := uuid.Parse(value); err != nil {
+		return fmt.Errorf("must be a valid UUID")
+	}
+	return nil
+}
@@ -44,6 +44,8 @@ func TestValidateUsername(t *testing.T) {
 		{"contains uppercase", "JohnDoe", true},
 		{"contains special chars", "john@doe", true},
 		{"contains spaces", "john doe", true},
+		{"system user", "system", true},
+		{"fees user", "fees", true},
 	}
 
 	for _, tt := range tests {
@@ -133,6 +135,27 @@ func TestValidateEmail(t *testing.T) {
 	}
 }
 
+func TestValidateToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"valid token", "v2.local.abcdef", false},
+		{"empty", "", true},
+		{"too long", string(make([]byte, 1025)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateToken(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateEmailId(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -179,3 +202,386 @@ func TestValidateSecretCode(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAccountId(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   int64
+		wantErr bool
+	}{
+		{"valid positive id", 1, false},
+		{"zero", 0, true},
+		{"negative", -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAccountId(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAccountId() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateId(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   int64
+		wantErr bool
+	}{
+		{"valid positive id", 1, false},
+		{"zero", 0, true},
+		{"negative", -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateId(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateId() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   int64
+		wantErr bool
+	}{
+		{"valid amount", 10, false},
+		{"zero", 0, true},
+		{"negative", -10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAmount(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAmount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateOverdraftLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   int64
+		wantErr bool
+	}{
+		{"valid limit", 10, false},
+		{"zero", 0, false},
+		{"negative", -10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOverdraftLimit(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOverdraftLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTransferLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   int64
+		wantErr bool
+	}{
+		{"valid limit", 10, false},
+		{"zero", 0, false},
+		{"negative", -10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransferLimit(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTransferLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"USD", "USD", false},
+		{"EUR", "EUR", false},
+		{"lowercase", "usd", true},
+		{"unsupported", "XYZ", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCurrency(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCurrency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCurrencyCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"supported", "USD", false},
+		{"not registered", "GBP", false},
+		{"lowercase", "gbp", true},
+		{"too short", "GB", true},
+		{"too long", "GBPP", true},
+		{"digits", "GB1", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCurrencyCode(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCurrencyCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMinorUnits(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   int32
+		wantErr bool
+	}{
+		{"zero", 0, false},
+		{"cents", 2, false},
+		{"max", 4, false},
+		{"negative", -1, true},
+		{"too many", 5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMinorUnits(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMinorUnits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCronExpression(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"every minute", "* * * * *", false},
+		{"monthly", "0 9 1 * *", false},
+		{"weekdays", "30 8 * * 1-5", false},
+		{"empty", "", true},
+		{"too few fields", "0 9 1 *", true},
+		{"out of range", "60 * * * *", true},
+		{"never fires", "0 0 31 2 *", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCronExpression(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCronExpression() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateIntervalSeconds(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   int64
+		wantErr bool
+	}{
+		{"minute", 60, false},
+		{"day", 86400, false},
+		{"too short", 59, true},
+		{"zero", 0, true},
+		{"negative", -60, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIntervalSeconds(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateIntervalSeconds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateHoldExpiresInSeconds(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   int64
+		wantErr bool
+	}{
+		{"minute", 60, false},
+		{"week", 7 * 24 * 60 * 60, false},
+		{"max", 30 * 24 * 60 * 60, false},
+		{"too short", 59, true},
+		{"too long", 30*24*60*60 + 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHoldExpiresInSeconds(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateHoldExpiresInSeconds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePageSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   int32
+		wantErr bool
+	}{
+		{"min size", 1, false},
+		{"max size", 100, false},
+		{"zero", 0, true},
+		{"too large", 101, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePageSize(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePageSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"uuid", "0b5a4bde-1ad5-4c3e-9c8e-4b5b3a8d6f2e", false},
+		{"exact min length", "abcdefgh", false},
+		{"too short", "abc", true},
+		{"too long", string(make([]byte, 256)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIdempotencyKey(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateIdempotencyKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDirection(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"incoming", "incoming", false},
+		{"outgoing", "outgoing", false},
+		{"empty", "", true},
+		{"unknown", "sideways", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDirection(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDirection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAccountType(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"personal", "personal", false},
+		{"business", "business", false},
+		{"empty", "", true},
+		{"unknown", "savings", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAccountType(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAccountType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTransferStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"pending", "pending", false},
+		{"completed", "completed", false},
+		{"failed", "failed", false},
+		{"reversed", "reversed", false},
+		{"cancelled", "cancelled", false},
+		{"empty", "", true},
+		{"upper case", "PENDING", true},
+		{"unknown", "settled", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransferStatus(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTransferStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSessionId(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"valid uuid", "8f2b4e2a-6c1d-4b7a-9f3e-2d5c7a1b0e94", false},
+		{"empty", "", true},
+		{"not a uuid", "session", true},
+		{"truncated uuid", "8f2b4e2a-6c1d-4b7a-9f3e", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSessionId(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSessionId() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	db "simple-bank/db/sqlc"
+	"time"
+)
+
+const defaultMaxAttempts = 10
+
+// Enqueues background tasks.
+// Every task is written with the given querier, so a task enqueued inside
+// a transaction is only picked up once that transaction commits.
+type TaskDistributor interface {
+	DistributeTaskSendVerifyEmail(
+		ctx context.Context,
+		q db.Querier,
+		payload *PayloadSendVerifyEmail,
+		opts ...Option,
+	) error
+}
+
+// Changes how a task is enqueued
+type Option func(arg *db.CreateTaskParams)
+
+// Number of times the task is run before it's dead lettered
+func MaxAttempts(n int32) Option {
+	return func(arg *db.CreateTaskParams) {
+		arg.MaxAttempts = n
+	}
+}
+
+// Delays the first run of the task
+func ProcessIn(delay time.Duration) Option {
+	return func(arg *db.CreateTaskParams) {
+		arg.RunAt = arg.RunAt.Add(delay)
+	}
+}
+
+// Enqueues tasks into the `tasks` table
+type PGTaskDistributor struct{}
+
+func NewPGTaskDistributor() TaskDistributor {
+	return &PGTaskDistributor{}
+}
+
+func (distributor *PGTaskDistributor) enqueue(
+	ctx context.Context,
+	q db.Querier,
+	taskType string,
+	payload any,
+	opts ...Option,
+) (db.Task, error) {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return db.Task{}, fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	arg := db.CreateTaskParams{
+		Type:        taskType,
+		Payload:     jsonPayload,
+		MaxAttempts: defaultMaxAttempts,
+		RunAt:       time.Now(),
+	}
+	for _, opt := range opts {
+		opt(&arg)
+	}
+
+	task, err := q.CreateTask(ctx, arg)
+	if err != nil {
+		return task, fmt.Errorf("failed to enqueue task: %w", err)
+	}
+
+	return task, nil
+}
@@ -0,0 +1,167 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "simple-bank/db/sqlc"
+	"simple-bank/mail"
+	"simple-bank/util"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Number of tasks processed at the same time
+	concurrency = 4
+	// How long a worker waits before looking for due tasks again when the queue is empty
+	pollInterval = time.Second
+	// How long a task may run before its lease expires and another worker can claim it
+	taskTimeout = time.Minute
+	// Delay before the first retry, doubled after every failed attempt
+	retryBaseDelay = time.Second
+	maxRetryDelay  = time.Hour
+)
+
+// Returned by a task handler when retrying can't help, eg: the payload is malformed.
+// The task is dead lettered right away.
+var errSkipRetry = errors.New("skip retry")
+
+// Runs the tasks enqueued by a `TaskDistributor`
+type TaskProcessor interface {
+	// Starts the workers in the background
+	Start() error
+	// Stops the workers after the tasks they are running have finished
+	Shutdown()
+	ProcessTaskSendVerifyEmail(ctx context.Context, task db.Task) error
+}
+
+type taskHandler func(ctx context.Context, task db.Task) error
+
+// Claims tasks from the `tasks` table, so any number of processes can run it side by side
+type PGTaskProcessor struct {
+	store    db.Store
+	mailer   mail.Sender
+	config   util.Config
+	handlers map[string]taskHandler
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewPGTaskProcessor(store db.Store, mailer mail.Sender, config util.Config) TaskProcessor {
+	processor := &PGTaskProcessor{
+		store:  store,
+		mailer: mailer,
+		config: config,
+		stop:   make(chan struct{}),
+	}
+
+	processor.handlers = map[string]taskHandler{
+		TaskSendVerifyEmail: processor.ProcessTaskSendVerifyEmail,
+	}
+
+	return processor
+}
+
+func (processor *PGTaskProcessor) Start() error {
+	for i := 0; i < concurrency; i++ {
+		processor.wg.Add(1)
+		go processor.run()
+	}
+	return nil
+}
+
+func (processor *PGTaskProcessor) Shutdown() {
+	close(processor.stop)
+	processor.wg.Wait()
+}
+
+func (processor *PGTaskProcessor) run() {
+	defer processor.wg.Done()
+
+	for {
+		select {
+		case <-processor.stop:
+			return
+		default:
+		}
+
+		processed, err := processor.processNext(context.Background())
+		if err != nil {
+			log.Error().Err(err).Msg("failed to process task")
+		}
+		if processed && err == nil {
+			continue
+		}
+
+		// The queue is empty or the database is unavailable
+		select {
+		case <-processor.stop:
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Claims and runs the next due task, reports false if there was none
+func (processor *PGTaskProcessor) processNext(ctx context.Context) (bool, error) {
+	lockedUntil := time.Now().Add(taskTimeout)
+	task, err := processor.store.ClaimTask(ctx, sql.NullTime{Time: lockedUntil, Valid: true})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim task: %w", err)
+	}
+
+	err = processor.handle(ctx, task)
+	if err == nil {
+		log.Info().Str("type", task.Type).Int64("task_id", task.ID).Msg("processed task")
+		return true, processor.store.CompleteTask(ctx, task.ID)
+	}
+
+	if errors.Is(err, errSkipRetry) || task.Attempts >= task.MaxAttempts {
+		log.Error().Err(err).Str("type", task.Type).Int64("task_id", task.ID).
+			Int32("attempts", task.Attempts).Msg("task is dead lettered")
+		return true, processor.store.DeadLetterTask(ctx, db.DeadLetterTaskParams{
+			ID:        task.ID,
+			LastError: err.Error(),
+		})
+	}
+
+	runAt := time.Now().Add(retryDelay(task.Attempts))
+	log.Warn().Err(err).Str("type", task.Type).Int64("task_id", task.ID).
+		Int32("attempts", task.Attempts).Time("run_at", runAt).Msg("task failed, will retry")
+	return true, processor.store.RetryTask(ctx, db.RetryTaskParams{
+		ID:        task.ID,
+		RunAt:     runAt,
+		LastError: err.Error(),
+	})
+}
+
+func (processor *PGTaskProcessor) handle(ctx context.Context, task db.Task) error {
+	handler, ok := processor.handlers[task.Type]
+	if !ok {
+		return fmt.Errorf("unknown task type %q: %w", task.Type, errSkipRetry)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, taskTimeout)
+	defer cancel()
+
+	return handler(ctx, task)
+}
+
+// Exponential backoff after the given number of failed attempts
+func retryDelay(attempts int32) time.Duration {
+	delay := retryBaseDelay
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
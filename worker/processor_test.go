@@ -0,0 +1,194 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"simple-bank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSender struct {
+	err  error
+	sent [][]string
+}
+
+func (sender *fakeSender) SendEmail(subject string, content string, to []string) error {
+	if sender.err != nil {
+		return sender.err
+	}
+	sender.sent = append(sender.sent, to)
+	return nil
+}
+
+func newSendVerifyEmailTask(t *testing.T, username string, attempts int32) db.Task {
+	payload, err := json.Marshal(PayloadSendVerifyEmail{Username: username})
+	require.NoError(t, err)
+
+	return db.Task{
+		ID:          util.RandomInt(1, 1000),
+		Type:        TaskSendVerifyEmail,
+		Payload:     payload,
+		Status:      "running",
+		Attempts:    attempts,
+		MaxAttempts: 3,
+	}
+}
+
+func TestProcessNext(t *testing.T) {
+	user := db.User{
+		Username: util.RandomOwner(),
+		FullName: util.RandomOwner(),
+		Email:    util.RandomEmail(),
+	}
+	verifyEmail := db.VerifyEmail{
+		ID:         1,
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: util.RandomString(32),
+	}
+
+	testCases := []struct {
+		name          string
+		task          db.Task
+		sendErr       error
+		buildStubs    func(store *mockdb.MockStore, task db.Task)
+		wantProcessed bool
+		wantSent      int
+	}{
+		{
+			name: "Completed",
+			task: newSendVerifyEmailTask(t, user.Username, 1),
+			buildStubs: func(store *mockdb.MockStore, task db.Task) {
+				store.EXPECT().ClaimTask(gomock.Any(), gomock.Any()).Times(1).Return(task, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().CreateVerifyEmail(gomock.Any(), gomock.Any()).Times(1).Return(verifyEmail, nil)
+				store.EXPECT().CompleteTask(gomock.Any(), gomock.Eq(task.ID)).Times(1).Return(nil)
+			},
+			wantProcessed: true,
+			wantSent:      1,
+		},
+		{
+			name:    "Retried",
+			task:    newSendVerifyEmailTask(t, user.Username, 2),
+			sendErr: errors.New("smtp unavailable"),
+			buildStubs: func(store *mockdb.MockStore, task db.Task) {
+				store.EXPECT().ClaimTask(gomock.Any(), gomock.Any()).Times(1).Return(task, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+				store.EXPECT().CreateVerifyEmail(gomock.Any(), gomock.Any()).Times(1).Return(verifyEmail, nil)
+				store.EXPECT().
+					RetryTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.RetryTaskParams) error {
+						require.Equal(t, task.ID, arg.ID)
+						require.Contains(t, arg.LastError, "smtp unavailable")
+						require.WithinDuration(t, time.Now().Add(retryDelay(2)), arg.RunAt, time.Second)
+						return nil
+					})
+			},
+			wantProcessed: true,
+		},
+		{
+			name:    "DeadLetteredAfterMaxAttempts",
+			task:    newSendVerifyEmailTask(t, user.Username, 3),
+			sendErr: errors.New("smtp unavailable"),
+			buildStubs: func(store *mockdb.MockStore, task db.Task) {
+				store.EXPECT().ClaimTask(gomock.Any(), gomock.Any()).Times(1).Return(task, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+				store.EXPECT().CreateVerifyEmail(gomock.Any(), gomock.Any()).Times(1).Return(verifyEmail, nil)
+				store.EXPECT().RetryTask(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeadLetterTask(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			wantProcessed: true,
+		},
+		{
+			name: "DeadLetteredUnknownType",
+			task: db.Task{ID: 1, Type: "task:unknown", Attempts: 1, MaxAttempts: 3},
+			buildStubs: func(store *mockdb.MockStore, task db.Task) {
+				store.EXPECT().ClaimTask(gomock.Any(), gomock.Any()).Times(1).Return(task, nil)
+				store.EXPECT().DeadLetterTask(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			wantProcessed: true,
+		},
+		{
+			name: "DeadLetteredMissingUser",
+			task: newSendVerifyEmailTask(t, user.Username, 1),
+			buildStubs: func(store *mockdb.MockStore, task db.Task) {
+				store.EXPECT().ClaimTask(gomock.Any(), gomock.Any()).Times(1).Return(task, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().DeadLetterTask(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			wantProcessed: true,
+		},
+		{
+			name: "EmptyQueue",
+			buildStubs: func(store *mockdb.MockStore, task db.Task) {
+				store.EXPECT().ClaimTask(gomock.Any(), gomock.Any()).Times(1).Return(db.Task{}, sql.ErrNoRows)
+			},
+			wantProcessed: false,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store, tc.task)
+
+			sender := &fakeSender{err: tc.sendErr}
+			processor := NewPGTaskProcessor(store, sender, util.Config{}).(*PGTaskProcessor)
+
+			processed, err := processor.processNext(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.wantProcessed, processed)
+			require.Len(t, sender.sent, tc.wantSent)
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	require.Equal(t, retryBaseDelay, retryDelay(1))
+	require.Equal(t, 2*retryBaseDelay, retryDelay(2))
+	require.Equal(t, 8*retryBaseDelay, retryDelay(4))
+	require.Equal(t, maxRetryDelay, retryDelay(100))
+}
+
+func TestDistributeTaskSendVerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	username := util.RandomOwner()
+
+	store.EXPECT().
+		CreateTask(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateTaskParams) (db.Task, error) {
+			require.Equal(t, TaskSendVerifyEmail, arg.Type)
+			require.JSONEq(t, `{"username":"`+username+`"}`, string(arg.Payload))
+			require.Equal(t, int32(5), arg.MaxAttempts)
+			require.WithinDuration(t, time.Now().Add(time.Minute), arg.RunAt, time.Second)
+			return db.Task{ID: 1, Type: arg.Type, MaxAttempts: arg.MaxAttempts}, nil
+		})
+
+	distributor := NewPGTaskDistributor()
+	err := distributor.DistributeTaskSendVerifyEmail(
+		context.Background(),
+		store,
+		&PayloadSendVerifyEmail{Username: username},
+		MaxAttempts(5),
+		ProcessIn(time.Minute),
+	)
+	require.NoError(t, err)
+}
@@ -0,0 +1,137 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	db "simple-bank/db/sqlc"
+	"simple-bank/recurrence"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// How long the scheduler waits before looking for due scheduled transfers again when none is due
+const schedulerPollInterval = 5 * time.Second
+
+// Executes scheduled transfers when they are due
+type TransferScheduler interface {
+	// Starts the scheduler in the background
+	Start() error
+	// Stops the scheduler after the transfer it is executing has finished
+	Shutdown()
+}
+
+// Claims due scheduled transfers with row locks, so any number of processes can run it side by side
+type PGTransferScheduler struct {
+	store db.Store
+	stop  chan struct{}
+	wg    sync.WaitGroup
+}
+
+func NewPGTransferScheduler(store db.Store) TransferScheduler {
+	return &PGTransferScheduler{
+		store: store,
+		stop:  make(chan struct{}),
+	}
+}
+
+func (scheduler *PGTransferScheduler) Start() error {
+	scheduler.wg.Add(1)
+	go scheduler.run()
+	return nil
+}
+
+func (scheduler *PGTransferScheduler) Shutdown() {
+	close(scheduler.stop)
+	scheduler.wg.Wait()
+}
+
+func (scheduler *PGTransferScheduler) run() {
+	defer scheduler.wg.Done()
+
+	for {
+		select {
+		case <-scheduler.stop:
+			return
+		default:
+		}
+
+		executed, err := scheduler.executeNext(context.Background())
+		if err != nil {
+			log.Error().Err(err).Msg("failed to execute scheduled transfer")
+		}
+		if executed && err == nil {
+			continue
+		}
+
+		// Nothing is due or the database is unavailable
+		select {
+		case <-scheduler.stop:
+			return
+		case <-time.After(schedulerPollInterval):
+		}
+	}
+}
+
+// Executes the most overdue scheduled transfer, reports false if none was due
+func (scheduler *PGTransferScheduler) executeNext(ctx context.Context) (bool, error) {
+	result, err := scheduler.store.ExecuteScheduledTransferTx(ctx, db.ExecuteScheduledTransferTxParams{
+		NextRunAt: func(scheduledTransfer db.ScheduledTransfer) (time.Time, bool, error) {
+			return NextScheduledRun(scheduledTransfer, time.Now())
+		},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	event := log.Info()
+	if result.Run.Status == db.ScheduledTransferRunFailed {
+		event = log.Warn().Str("error", result.Run.Error)
+	}
+	event.Int64("scheduled_transfer_id", result.ScheduledTransfer.ID).
+		Time("scheduled_for", result.Run.ScheduledFor).
+		Str("status", result.Run.Status).
+		Msg("executed scheduled transfer")
+
+	return true, nil
+}
+
+// Builds the recurrence of a scheduled transfer, nil for a one-off.
+// Interval schedules are anchored on `start`.
+func ScheduledTransferRecurrence(cronExpression sql.NullString, intervalSeconds sql.NullInt64, start time.Time) (recurrence.Schedule, error) {
+	switch {
+	case cronExpression.Valid && intervalSeconds.Valid:
+		return nil, fmt.Errorf("a scheduled transfer recurs with either a cron expression or an interval")
+	case cronExpression.Valid:
+		return recurrence.ParseCron(cronExpression.String)
+	case intervalSeconds.Valid:
+		return recurrence.NewInterval(start, time.Duration(intervalSeconds.Int64)*time.Second)
+	default:
+		return nil, nil
+	}
+}
+
+// Returns the first occurrence after `after`, false for a one-off.
+// Occurrences missed while no scheduler was running are skipped instead of executed back to back.
+func NextScheduledRun(scheduledTransfer db.ScheduledTransfer, after time.Time) (time.Time, bool, error) {
+	schedule, err := ScheduledTransferRecurrence(
+		scheduledTransfer.CronExpression,
+		scheduledTransfer.IntervalSeconds,
+		scheduledTransfer.NextRunAt,
+	)
+	if err != nil || schedule == nil {
+		return time.Time{}, false, err
+	}
+
+	// The current occurrence is never returned again, even if this clock is behind the database's
+	if after.Before(scheduledTransfer.NextRunAt) {
+		after = scheduledTransfer.NextRunAt
+	}
+
+	return schedule.Next(after), true, nil
+}
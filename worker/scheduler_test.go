@@ -0,0 +1,175 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	mockdb "simple-bank/db/mock"
+	db "simple-bank/db/sqlc"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextScheduledRun(t *testing.T) {
+	nextRunAt := time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name      string
+		transfer  db.ScheduledTransfer
+		after     time.Time
+		wantNext  time.Time
+		wantOK    bool
+		wantError bool
+	}{
+		{
+			name:     "OneOff",
+			transfer: db.ScheduledTransfer{NextRunAt: nextRunAt},
+			after:    nextRunAt,
+			wantOK:   false,
+		},
+		{
+			name: "Cron",
+			transfer: db.ScheduledTransfer{
+				CronExpression: sql.NullString{String: "0 9 1 * *", Valid: true},
+				NextRunAt:      nextRunAt,
+			},
+			after:    nextRunAt.Add(time.Second),
+			wantNext: time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC),
+			wantOK:   true,
+		},
+		{
+			name: "Interval",
+			transfer: db.ScheduledTransfer{
+				IntervalSeconds: sql.NullInt64{Int64: 3600, Valid: true},
+				NextRunAt:       nextRunAt,
+			},
+			after:    nextRunAt.Add(time.Minute),
+			wantNext: nextRunAt.Add(time.Hour),
+			wantOK:   true,
+		},
+		{
+			name: "MissedOccurrencesSkipped",
+			transfer: db.ScheduledTransfer{
+				IntervalSeconds: sql.NullInt64{Int64: 3600, Valid: true},
+				NextRunAt:       nextRunAt,
+			},
+			after:    nextRunAt.Add(5*time.Hour + time.Minute),
+			wantNext: nextRunAt.Add(6 * time.Hour),
+			wantOK:   true,
+		},
+		{
+			name: "ClockBehind",
+			transfer: db.ScheduledTransfer{
+				IntervalSeconds: sql.NullInt64{Int64: 3600, Valid: true},
+				NextRunAt:       nextRunAt,
+			},
+			after:    nextRunAt.Add(-time.Minute),
+			wantNext: nextRunAt.Add(time.Hour),
+			wantOK:   true,
+		},
+		{
+			name: "InvalidCron",
+			transfer: db.ScheduledTransfer{
+				CronExpression: sql.NullString{String: "every day", Valid: true},
+				NextRunAt:      nextRunAt,
+			},
+			after:     nextRunAt,
+			wantError: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			next, ok, err := NextScheduledRun(tc.transfer, tc.after)
+			if tc.wantError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantOK, ok)
+			require.True(t, tc.wantNext.Equal(next), "next run %v, want %v", next, tc.wantNext)
+		})
+	}
+}
+
+func TestExecuteNext(t *testing.T) {
+	testCases := []struct {
+		name         string
+		buildStubs   func(store *mockdb.MockStore)
+		wantExecuted bool
+		wantError    bool
+	}{
+		{
+			name: "Executed",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExecuteScheduledTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteScheduledTransferTxResult{
+						Run: db.ScheduledTransferRun{Status: db.ScheduledTransferRunCompleted},
+					}, nil)
+			},
+			wantExecuted: true,
+		},
+		{
+			name: "FailedRun",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExecuteScheduledTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteScheduledTransferTxResult{
+						Run: db.ScheduledTransferRun{Status: db.ScheduledTransferRunFailed, Error: db.ErrInsufficientFunds.Error()},
+					}, nil)
+			},
+			wantExecuted: true,
+		},
+		{
+			name: "NothingDue",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExecuteScheduledTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteScheduledTransferTxResult{}, sql.ErrNoRows)
+			},
+			wantExecuted: false,
+		},
+		{
+			name: "DatabaseError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ExecuteScheduledTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteScheduledTransferTxResult{}, sql.ErrConnDone)
+			},
+			wantExecuted: false,
+			wantError:    true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			scheduler := NewPGTransferScheduler(store).(*PGTransferScheduler)
+
+			executed, err := scheduler.executeNext(context.Background())
+			require.Equal(t, tc.wantExecuted, executed)
+			if tc.wantError {
+				require.True(t, errors.Is(err, sql.ErrConnDone))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
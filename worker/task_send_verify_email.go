@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"html"
+	db "simple-bank/db/sqlc"
+	"simple-bank/util"
+
+	"github.com/rs/zerolog/log"
+)
+
+const TaskSendVerifyEmail = "task:send_verify_email"
+
+type PayloadSendVerifyEmail struct {
+	Username string `json:"username"`
+}
+
+func (distributor *PGTaskDistributor) DistributeTaskSendVerifyEmail(
+	ctx context.Context,
+	q db.Querier,
+	payload *PayloadSendVerifyEmail,
+	opts ...Option,
+) error {
+	task, err := distributor.enqueue(ctx, q, TaskSendVerifyEmail, payload, opts...)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Str("type", task.Type).Int64("task_id", task.ID).
+		Int32("max_attempts", task.MaxAttempts).Msg("enqueued task")
+	return nil
+}
+
+// Creates a verification code for the email of the user and mails the link to verify it
+func (processor *PGTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, task db.Task) error {
+	var payload PayloadSendVerifyEmail
+	if err := json.Unmarshal(task.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", errSkipRetry)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("user doesn't exist: %w", errSkipRetry)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	verifyEmail, err := processor.store.CreateVerifyEmail(ctx, db.CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: util.RandomString(32),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create verify email: %w", err)
+	}
+
+	verifyURL := fmt.Sprintf("%s?email_id=%d&secret_code=%s",
+		processor.config.VerifyEmailURL, verifyEmail.ID, verifyEmail.SecretCode)
+	subject := "Welcome to Simple Bank"
+	content := fmt.Sprintf(`Hello %s,<br/>
+	Thank you for registering with us!<br/>
+	Please <a href="%s">click here</a> to verify your email address.<br/>
+	`, html.EscapeString(user.FullName), verifyURL)
+
+	err = processor.mailer.SendEmail(subject, content, []string{verifyEmail.Email})
+	if err != nil {
+		return fmt.Errorf("failed to send verify email: %w", err)
+	}
+
+	return nil
+}